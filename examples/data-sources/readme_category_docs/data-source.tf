@@ -7,3 +7,18 @@ output "category_docs" {
   value = data.readme_category_docs.example
 }
 
+
+# Iterate over the docs at any depth with the flat list. Hidden docs and docs
+# nested more than one level deep are left out, and the body of each doc is
+# retrieved with up to 4 concurrent requests.
+data "readme_category_docs" "flat" {
+  slug             = "example"
+  include_hidden   = false
+  max_depth        = 1
+  include_body     = true
+  body_concurrency = 4
+}
+
+output "category_doc_paths" {
+  value = { for doc in data.readme_category_docs.flat.flat : doc.path => doc.title }
+}
@@ -7,3 +7,18 @@ output "example_doc" {
   value = data.readme_doc.example
 }
 
+# Retrieve a doc by its ID.
+data "readme_doc" "by_id" {
+  id = "63b891d3ee384600680ce9ea"
+}
+
+# Retrieve a doc by its title in a category.
+data "readme_doc" "by_title" {
+  category_slug = "getting-started"
+  title         = "Linux"
+}
+
+# Retrieve a doc by its path of slugs, starting with the category slug.
+data "readme_doc" "by_path" {
+  path = "getting-started/install/linux"
+}
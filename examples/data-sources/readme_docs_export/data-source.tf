@@ -0,0 +1,17 @@
+# Export all docs of a version as Markdown files.
+data "readme_docs_export" "backup" {
+  version    = "2.1"
+  output_dir = "${path.module}/backup/2.1"
+}
+
+# The exported files can be managed in another version.
+resource "readme_docs_directory" "migrated" {
+  path    = "${path.module}/backup/2.1"
+  version = "3.0"
+
+  depends_on = [data.readme_docs_export.backup]
+}
+
+output "exported_files" {
+  value = keys(data.readme_docs_export.backup.files)
+}
@@ -0,0 +1,6 @@
+data "readme_project" "example" {}
+
+# Returns the URL of the doc, such as "https://docs.example.com/v1.1/docs/getting-started".
+output "doc_url" {
+  value = provider::readme::doc_url(data.readme_project.example.base_url, "1.1", "getting-started")
+}
@@ -0,0 +1,8 @@
+# Read the title from the front matter of a Markdown file.
+locals {
+  frontmatter = provider::readme::parse_frontmatter(file("${path.module}/docs/getting-started.md"))
+}
+
+output "title" {
+  value = local.frontmatter.title
+}
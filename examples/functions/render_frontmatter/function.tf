@@ -0,0 +1,11 @@
+# Set the title and category of a Markdown file in its front matter.
+resource "readme_doc" "example" {
+  body = provider::readme::render_frontmatter(
+    {
+      title        = "Getting Started"
+      categorySlug = "guides"
+      hidden       = false
+    },
+    file("${path.module}/docs/getting-started.md"),
+  )
+}
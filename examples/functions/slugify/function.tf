@@ -0,0 +1,4 @@
+# Returns "getting-started".
+output "slug" {
+  value = provider::readme::slugify("Getting Started")
+}
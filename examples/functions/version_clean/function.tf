@@ -0,0 +1,4 @@
+# Returns "1.2.0".
+output "version" {
+  value = provider::readme::version_clean("v1.2.0")
+}
@@ -5,6 +5,23 @@ provider "readme" {
 
   # Set the API token here or with the README_API_TOKEN env var.
   # api_token = ""
+
+  # Alternatively, read the API token from a file or the output of a command.
+  # api_token_file    = "/run/secrets/readme-api-token"
+  # api_token_command = ["op", "read", "op://docs/readme/api-token"]
+
+  # Optionally fail if the API token is for a different project than expected.
+  # expected_project_subdomain = "example"
+
+  # Optionally limit the number of concurrent requests to the ReadMe API.
+  # max_concurrent_requests = 5
+
+  # Optionally adjust how rate limited and failed API requests are retried.
+  # retry = {
+  #   max_attempts = 4
+  #   min_backoff  = 1
+  #   max_backoff  = 30
+  # }
 }
 
 terraform {
@@ -4,3 +4,7 @@
 # Terraform will replace the remote definition on its next run, regardless if it
 # differs from the local definition.
 terraform import readme_api_specification.example 639fd743a9690100813a13fd
+
+# Import a specification from a specific version by its ID or title using
+# `version/identifier`.
+terraform import readme_api_specification.example "2.0/Petstore API"
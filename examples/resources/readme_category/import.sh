@@ -1,2 +1,6 @@
 # Import a ReadMe category using its slug.
 terraform import readme_category.example example-slug
+
+# Import a category from a specific version by its slug or title using
+# `version/identifier`.
+terraform import readme_category.example 2.0/example-slug
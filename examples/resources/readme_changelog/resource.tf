@@ -8,3 +8,9 @@ resource "readme_changelog" "example" {
   # body can be read from a file using Terraform's `file()` or `templatefile()` functions.
   body = "* Added support for foo\n* Added support for bar"
 }
+
+# Read the changelog from a Markdown file. Only a hash of the contents is
+# stored in the state, and front matter in the file is used for attributes.
+resource "readme_changelog" "from_file" {
+  body_file = "${path.module}/changelog.md"
+}
@@ -10,6 +10,12 @@ resource "readme_custom_page" "example" {
   body = file("my-custom-page.md")
 }
 
+# Read the custom page from a Markdown file. Only a hash of the contents is
+# stored in the state, and front matter in the file is used for attributes.
+resource "readme_custom_page" "from_file" {
+  body_file = "${path.module}/my-custom-page.md"
+}
+
 # Example using HTML.
 resource "readme_custom_page" "example_html" {
   title     = "My Example Custom Page"
@@ -1,2 +1,9 @@
 # Import a ReadMe doc using its slug.
 terraform import readme_doc.example example-slug
+
+# Import a doc from a specific version using `version/slug`.
+terraform import readme_doc.example 2.0/example-slug
+
+# Import a doc by its slug or title within a category using
+# `version/category/identifier`. Colons may be used instead of slashes.
+terraform import readme_doc.example "2.0:guides:Getting Started"
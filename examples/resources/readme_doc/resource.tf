@@ -27,9 +27,69 @@ resource "readme_doc" "example" {
   # type can be specified as an attribute or in the body front matter.
   type = "basic"
 
+  # excerpt can be specified as an attribute or in the body front matter.
+  excerpt = "A short summary of my example doc."
+
+  # metadata sets the SEO title, description, and images of the doc. It can be
+  # specified as an attribute or in the body front matter.
+  metadata = {
+    title       = "My Example Doc | Example"
+    description = "An example doc managed with Terraform."
+    image       = ["https://example.com/example.png"]
+  }
+
   # body can be read from a file using Terraform's `file()` function.
   # For best results, wrap the string with the `chomp()` function to remove
   # trailing newlines. ReadMe's API trims these implicitly.
   #body = chomp(file("mydoc.md"))
   body = "Hello! Welcome to my document!"
 }
+
+# Create a doc that redirects to an external link.
+resource "readme_doc" "link" {
+  title         = "Example Link"
+  category      = readme_category.example.id
+  type          = "link"
+  link_url      = "https://example.com"
+  link_external = true
+}
+
+# Create a doc from a Markdown file. The provider reads the file itself, so
+# only a hash of the contents is stored in the state. Front matter in the file
+# is used to set attributes the same as with `body`.
+resource "readme_doc" "from_file" {
+  category  = readme_category.example.id
+  body_file = "${path.module}/mydoc.md"
+}
+
+# Render the body as a template with variables and snippets shared across docs.
+# Variables are referenced as `{{ .Name }}` and snippets in the snippet
+# directories are included with `{{ include "name.md" }}`. The rendered body is
+# available in the `body_rendered` attribute.
+resource "readme_doc" "templated" {
+  title    = "Installing"
+  category = readme_category.example.id
+  body     = <<-EOT
+    Install {{ .Product }} with the following command:
+
+    {{ include "install.md" }}
+    EOT
+
+  template_vars = {
+    Product = "Example"
+  }
+  snippet_dirs = ["${path.module}/snippets"]
+}
+
+# Check links to other docs and images in the body when planning. Referencing
+# the slug of a doc created in the same configuration ensures it's planned
+# first so the link is recognized.
+resource "readme_doc" "linked" {
+  title      = "Next Steps"
+  category   = readme_category.example.id
+  link_check = "error"
+  body       = <<-EOT
+    Start by [installing](doc:${readme_doc.templated.slug}), then read the
+    [API reference](ref:get-pets).
+    EOT
+}
@@ -0,0 +1,19 @@
+# Copy docs between versions on ReadMe.
+
+# Promote a doc and its children from version 2.1 to version 3.0.
+resource "readme_doc_copy" "promote" {
+  slug             = "getting-started"
+  source_version   = "2.1"
+  target_version   = "3.0"
+  include_children = true
+}
+
+# Backport a corrected doc to an older version and keep it up to date when the
+# source doc changes.
+resource "readme_doc_copy" "backport" {
+  slug                 = "authentication"
+  source_version       = "3.0"
+  target_version       = "2.1"
+  target_category_slug = "guides"
+  sync                 = true
+}
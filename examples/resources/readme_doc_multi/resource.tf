@@ -0,0 +1,10 @@
+# Manage the same doc in multiple versions on ReadMe.
+
+# Keep the terms of service in every active version.
+resource "readme_doc_multi" "terms" {
+  title         = "Terms of Service"
+  body          = file("docs/terms-of-service.md")
+  category_slug = "legal"
+  slug          = "terms-of-service"
+  versions      = ["2.1", "3.0", "3.1"]
+}
@@ -0,0 +1,10 @@
+# Import the order of the top level docs in a category using its slug.
+terraform import readme_doc_order.example example-category
+
+# Import the order of docs in a category from a specific version using
+# `version/category`.
+terraform import readme_doc_order.example 2.0/example-category
+
+# Import the order of the child docs of a parent doc using
+# `version/category/parent`.
+terraform import readme_doc_order.children 2.0/example-category/usage
@@ -0,0 +1,29 @@
+# Manage the order of docs in a category on ReadMe.
+
+# Order the top level docs in a category.
+resource "readme_doc_order" "example" {
+  category_slug = readme_category.example.slug
+
+  # The docs are ordered as listed. Only the docs that are out of order are
+  # updated.
+  slugs = [
+    readme_doc.overview.slug,
+    readme_doc.install.slug,
+    readme_doc.usage.slug,
+  ]
+
+  # Docs in the category that aren't listed are left alone by default. Set
+  # `unlisted` to "append" to order them after the listed docs.
+  unlisted = "append"
+}
+
+# Order the child docs of a parent doc.
+resource "readme_doc_order" "children" {
+  category_slug   = readme_category.example.slug
+  parent_doc_slug = readme_doc.usage.slug
+
+  slugs = [
+    "basic-usage",
+    "advanced-usage",
+  ]
+}
@@ -0,0 +1,3 @@
+# Import a docs directory using its path. Existing docs are matched to the files
+# by slug on the next apply.
+terraform import readme_docs_directory.example ./docs
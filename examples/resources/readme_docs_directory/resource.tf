@@ -0,0 +1,18 @@
+# Sync every Markdown file in the docs directory to ReadMe.
+#
+# Each file sets its attributes in front matter, for example:
+#
+# ---
+# title: Getting Started
+# categorySlug: guides
+# parentDocSlug: overview
+# order: 1
+# hidden: false
+# ---
+resource "readme_docs_directory" "guides" {
+  path    = "${path.module}/docs"
+  version = "1.1"
+
+  # Used for files that don't set `category` or `categorySlug` in front matter.
+  category_slug = "guides"
+}
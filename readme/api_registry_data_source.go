@@ -96,10 +96,12 @@ func (d *apiRegistryDataSource) Read(
 	// Get the specification from the API registry
 	registry, apiResponse, err := d.client.APIRegistry.Get(uuid)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(clientDiagnostic(
 			"Unable to retrieve API registry metadata.",
-			clientError(err, apiResponse),
-		)
+			err,
+			apiResponse,
+			nil,
+		))
 
 		return
 	}
@@ -130,5 +132,5 @@ func (d *apiRegistryDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
@@ -65,7 +65,7 @@ func (d *apiSpecificationDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
 
 // Schema defines the schema for the data source Terraform attributes.
@@ -182,10 +182,12 @@ func (d *apiSpecificationDataSource) Read(
 		// Get API specification by ID.
 		apiSpec, apiResponse, err = d.client.APISpecification.Get(state.ID.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(clientDiagnostic(
 				"Unable to retrieve API specification metadata.",
-				clientError(err, apiResponse),
-			)
+				err,
+				apiResponse,
+				nil,
+			))
 
 			return
 		}
@@ -203,10 +205,12 @@ func (d *apiSpecificationDataSource) Read(
 		// Get all API specifications.
 		apiSpecs, apiResponse, err := d.client.APISpecification.GetAll()
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(clientDiagnostic(
 				"Unable to retrieve API specifications.",
-				clientError(err, apiResponse),
-			)
+				err,
+				apiResponse,
+				nil,
+			))
 
 			return
 		}
@@ -49,8 +49,9 @@ var (
 
 // apiSpecResource is the resource implementation.
 type apiSpecResource struct {
-	client *readme.Client
-	config providerConfig
+	client  *readme.Client
+	config  providerConfig
+	lookups *lookupCache
 }
 
 // apiSpecResourceModel maps the struct from the ReadMe client library to Terraform attributes.
@@ -87,6 +88,7 @@ func (r *apiSpecResource) Configure(_ context.Context, req resource.ConfigureReq
 	cfg := req.ProviderData.(*providerData)
 	r.client = cfg.client
 	r.config = cfg.config
+	r.lookups = cfg.lookups
 }
 
 // specCategoryObject maps a readme.CategorySummary type to a generic ObjectValue and returns the ObjectValue for use
@@ -192,6 +194,10 @@ func (r *apiSpecResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 
 // Create creates the API Specification and sets the initial Terraform state.
 func (r *apiSpecResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "API specification") {
+		return
+	}
+
 	// Retrieve values from the plan.
 	var plan apiSpecResourceModel
 	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
@@ -248,10 +254,12 @@ func (r *apiSpecResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 				return
 			}
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(clientDiagnostic(
 				"Unable to read API specification",
-				clientError(err, apiResponse),
-			)
+				err,
+				apiResponse,
+				nil,
+			))
 
 			return
 		}
@@ -293,6 +301,10 @@ func (r *apiSpecResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update updates the API Specification and sets the updated Terraform state on success.
 func (r *apiSpecResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.config.readOnly(&resp.Diagnostics, "update", "API specification") {
+		return
+	}
+
 	// Retrieve values from plan and current state.
 	var plan, state apiSpecResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -323,6 +335,10 @@ func (r *apiSpecResource) Update(ctx context.Context, req resource.UpdateRequest
 
 // Delete deletes the API Specification and removes the Terraform state on success.
 func (r *apiSpecResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.config.readOnly(&resp.Diagnostics, "delete", "API specification") {
+		return
+	}
+
 	// Retrieve values from state.
 	var state apiSpecResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -332,10 +348,12 @@ func (r *apiSpecResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	// Delete the API Specification.
 	if _, apiResponse, err := r.client.APISpecification.Delete(state.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(clientDiagnostic(
 			"Unable to delete API specification",
-			clientError(err, apiResponse),
-		)
+			err,
+			apiResponse,
+			nil,
+		))
 
 		return
 	}
@@ -357,28 +375,66 @@ func (r *apiSpecResource) deleteCategory(
 
 	// Clean the version ID from the state.
 	versionID := state.Version.ValueString()
-	version := versionClean(ctx, r.client, versionID)
+	version := r.lookups.versionClean(ctx, r.client, versionID)
 
 	// Delete the category.
 	opts := readme.RequestOptions{Version: version}
-	if _, apiResponse, err := r.client.Category.Delete(catSlug, opts); err != nil {
-		resp.Diagnostics.AddError(
+	_, apiResponse, err := r.client.Category.Delete(catSlug, opts)
+	r.lookups.invalidate(lookupCategory)
+	if err != nil {
+		resp.Diagnostics.Append(clientDiagnostic(
 			"Unable to delete category",
-			clientError(err, apiResponse),
-		)
+			err,
+			apiResponse,
+			apiErrorPaths{"CATEGORY_NOTFOUND": path.Empty()},
+		))
 
 		return
 	}
 }
 
-// ImportState imports an API Specification by ID.
+// ImportState imports an API Specification by ID or title, optionally qualified with a version.
 func (r *apiSpecResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	// Use the "id" attribute for importing.
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id, err := parseImportID(req.ID, r.config.DefaultVersion.ValueString(), false)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID.", err.Error())
+
+		return
+	}
+
+	specs, apiResponse, err := r.client.APISpecification.GetAll(id.options())
+	if err != nil {
+		resp.Diagnostics.Append(clientDiagnostic("Unable to import API specification.", err, apiResponse, nil))
+
+		return
+	}
+
+	matches := make([]importMatch, 0, len(specs))
+	for _, spec := range specs {
+		matches = append(matches, importMatch{id: spec.ID, title: spec.Title})
+	}
+
+	match, err := matchImportID("API specification", id, matches)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import API specification.", err.Error())
+
+		return
+	}
+
+	// The version ID is used to read the specification from the matching version.
+	for _, spec := range specs {
+		if spec.ID == match.id {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version"), spec.Version)...)
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("importing API specification %s with version '%s'", match.id, id.version))
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), match.id)...)
 }
 
 // jsonMatch compares two JSON strings without regards to formatting and returns a bool.
@@ -420,8 +476,12 @@ type saveParams struct {
 // After creation or update, the specification is retrieved and `makePlan()` is called to map the results to the
 // Terraform resource schema that is returned.
 func (r *apiSpecResource) save(params saveParams) (apiSpecResourceModel, error) {
-	// Determine the version, preferring semver if specified.
+	// Determine the version, preferring semver if specified. New specifications use the provider's
+	// default version otherwise.
 	version := params.plan.Semver.ValueString()
+	if version == "" && params.action == saveActionCreate {
+		version = r.config.DefaultVersion.ValueString()
+	}
 
 	// Upload the API specification to the registry.
 	registry, err := r.createRegistry(params.plan.Definition.ValueString(), version)
@@ -77,7 +77,7 @@ func (d *apiSpecificationsDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
 
 // Schema defines the schema for the data source Terraform attributes.
@@ -233,10 +233,12 @@ func (d *apiSpecificationsDataSource) Read(
 		tflog.Info(ctx, fmt.Sprintf("Getting API specifications with request options: %v", versionFilter))
 		apiSpecs, apiResponse, err = d.client.APISpecification.GetAll(versionFilter)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			resp.Diagnostics.Append(clientDiagnostic(
 				"Unable to retrieve API specifications.",
-				clientError(err, apiResponse),
-			)
+				err,
+				apiResponse,
+				nil,
+			))
 
 			return
 		}
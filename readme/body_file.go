@@ -0,0 +1,126 @@
+package readme
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/frontmatter"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/markdown"
+)
+
+// bodyFileDescription is the description of the `body_file` attribute shared by the changelog,
+// custom page, and doc resources.
+const bodyFileDescription = "The path to a Markdown file to read the body from instead of the `body` attribute. " +
+	"The provider reads the file when planning, so only a hash of the contents is stored in the state and " +
+	"plans show a change to `body_hash` when the contents change. Front matter in the file is used to set " +
+	"attributes the same as with `body`. Relative paths are relative to the directory Terraform runs in, so " +
+	"prefer `\"${path.module}/example.md\"`. Conflicts with `body`."
+
+// bodyFormattingDescription describes the semantic equality of the `body` attribute.
+const bodyFormattingDescription = " Formatting that ReadMe normalizes when it saves the body, such as trailing " +
+	"whitespace, repeated blank lines, escaped newlines, and list markers, isn't shown as a change."
+
+// bodyCleanDeprecation is the deprecation message of the `body_clean` attribute.
+const bodyCleanDeprecation = "Use `body` instead. Formatting differences that ReadMe normalizes are no longer " +
+	"shown as changes to `body`, so `body_clean` will be removed in a future version."
+
+// bodyHashDescription is the description of the `body_hash` attribute.
+const bodyHashDescription = "The SHA-256 hash of the `body_file` contents. This is null when `body_file` isn't set."
+
+// bodyFileHash returns the hex encoded SHA-256 hash of a body file's contents.
+func bodyFileHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// planBodyHash reads the file set by the `body_file` attribute and returns the hash of its contents
+// for the plan.
+//
+// A null value is returned if `body_file` isn't set and an unknown value is returned if the path
+// isn't known yet.
+func planBodyHash(bodyFile types.String, diags *diag.Diagnostics) types.String {
+	if bodyFile.IsNull() {
+		return types.StringNull()
+	}
+
+	if bodyFile.IsUnknown() {
+		return types.StringUnknown()
+	}
+
+	content, err := frontmatter.ReadBody(types.StringNull(), bodyFile)
+	if err != nil {
+		diags.AddAttributeError(path.Root("body_file"), "Unable to read body file.", err.Error())
+
+		return types.StringUnknown()
+	}
+
+	return types.StringValue(bodyFileHash(content))
+}
+
+// applyBody returns the body to send to the API and the hash of the body file to save in the
+// state.
+//
+// When the `body_file` attribute is set, the file is read again and must match the planned hash
+// so the state reflects the contents that were reviewed in the plan.
+func applyBody(body, bodyFile, bodyHash types.String) (string, types.String, error) {
+	if bodyFile.IsNull() {
+		return body.ValueString(), types.StringNull(), nil
+	}
+
+	content, err := frontmatter.ReadBody(types.StringNull(), bodyFile)
+	if err != nil {
+		return "", bodyHash, err
+	}
+
+	hash := bodyFileHash(content)
+	if !bodyHash.IsUnknown() && bodyHash.ValueString() != hash {
+		return "", bodyHash, fmt.Errorf(
+			"the file %s changed after the plan was created; run the plan again to review the changes",
+			bodyFile.ValueString(),
+		)
+	}
+
+	return content, types.StringValue(hash), nil
+}
+
+// stateBody returns the body to save in the state from the body returned by the API.
+//
+// The body from the API can only be compared to the configured body when it isn't read from a
+// file or rendered from a template. Otherwise the configured body is kept. The semantic equality of
+// the `markdown` type keeps the configured body when it only differs by ReadMe's formatting, so
+// only changes to the content made outside of Terraform are shown as a difference.
+func stateBody(body markdown.Value, apiBody string, bodyFile types.String, vars types.Map, snippetDirs types.List) markdown.Value {
+	if body.IsNull() || body.IsUnknown() || !bodyFile.IsNull() || !vars.IsNull() || !snippetDirs.IsNull() {
+		return body
+	}
+
+	return markdown.NewValue(apiBody)
+}
+
+// validateBodyConfig verifies that only one of the `body` or `body_file` attributes is set.
+//
+// If `required` is true, one of them must be set.
+func validateBodyConfig(body, bodyFile types.String, required bool, diags *diag.Diagnostics) {
+	if !body.IsNull() && !bodyFile.IsNull() {
+		diags.AddAttributeError(
+			path.Root("body_file"),
+			"Conflicting attributes.",
+			"Only one of 'body' or 'body_file' may be set.",
+		)
+
+		return
+	}
+
+	if required && body.IsNull() && bodyFile.IsNull() {
+		diags.AddAttributeError(
+			path.Root("body"),
+			"Missing required attribute.",
+			"One of 'body' or 'body_file' must be set.",
+		)
+	}
+}
@@ -0,0 +1,184 @@
+package readme
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/markdown"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestPlanBodyHash(t *testing.T) {
+	dir := writeDocsDirectory(t, map[string]string{"doc.md": "---\ntitle: Doc\n---\nBody\n"})
+	file := filepath.Join(dir, "doc.md")
+
+	var diags diag.Diagnostics
+	if hash := planBodyHash(types.StringNull(), &diags); !hash.IsNull() {
+		t.Errorf("expected a null hash without a body file, got %s", hash)
+	}
+	if hash := planBodyHash(types.StringUnknown(), &diags); !hash.IsUnknown() {
+		t.Errorf("expected an unknown hash for an unknown body file, got %s", hash)
+	}
+
+	hash := planBodyHash(types.StringValue(file), &diags)
+	if hash.ValueString() != bodyFileHash("---\ntitle: Doc\n---\nBody\n") {
+		t.Errorf("unexpected hash %s", hash)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	planBodyHash(types.StringValue(filepath.Join(dir, "missing.md")), &diags)
+	if !diags.HasError() {
+		t.Error("expected an error for a missing body file")
+	}
+}
+
+func TestApplyBody(t *testing.T) {
+	dir := writeDocsDirectory(t, map[string]string{"doc.md": "Body\n"})
+	file := types.StringValue(filepath.Join(dir, "doc.md"))
+
+	body, hash, err := applyBody(types.StringValue("Inline"), types.StringNull(), types.StringNull())
+	if err != nil || body != "Inline" || !hash.IsNull() {
+		t.Errorf("expected the inline body, got '%s', %s, %v", body, hash, err)
+	}
+
+	body, hash, err = applyBody(types.StringNull(), file, types.StringUnknown())
+	if err != nil || body != "Body\n" || hash.ValueString() != bodyFileHash("Body\n") {
+		t.Errorf("expected the file body, got '%s', %s, %v", body, hash, err)
+	}
+
+	// The file must not change between the plan and apply.
+	if err := os.WriteFile(file.ValueString(), []byte("Changed\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = applyBody(types.StringNull(), file, hash)
+	if err == nil || !strings.Contains(err.Error(), "changed after the plan") {
+		t.Errorf("expected an error for a changed file, got %v", err)
+	}
+}
+
+func TestStateBody(t *testing.T) {
+	body := markdown.NewValue("* Configured  ")
+	vars := types.MapNull(types.StringType)
+	dirs := types.ListNull(types.StringType)
+
+	// The body from the API is used so it can be compared to the configured body.
+	got := stateBody(body, "- Configured", types.StringNull(), vars, dirs)
+	if got.ValueString() != "- Configured" {
+		t.Errorf("expected the body from the API, got %s", got)
+	}
+
+	// The configured body is kept when it can't be compared to the body from the API.
+	tests := map[string]struct {
+		body     markdown.Value
+		bodyFile types.String
+		vars     types.Map
+		dirs     types.List
+	}{
+		"null body":    {markdown.NewNullValue(), types.StringNull(), vars, dirs},
+		"unknown body": {markdown.NewUnknownValue(), types.StringNull(), vars, dirs},
+		"body file":    {body, types.StringValue("doc.md"), vars, dirs},
+		"template vars": {
+			body, types.StringNull(), types.MapValueMust(types.StringType, map[string]attr.Value{}), dirs,
+		},
+		"snippet dirs": {
+			body, types.StringNull(), vars, types.ListValueMust(types.StringType, []attr.Value{}),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := stateBody(tc.body, "API body", tc.bodyFile, tc.vars, tc.dirs)
+			if !got.Equal(tc.body) {
+				t.Errorf("expected the configured body %s, got %s", tc.body, got)
+			}
+		})
+	}
+}
+
+func TestValidateBodyConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     types.String
+		bodyFile types.String
+		required bool
+		err      string
+	}{
+		{"body", types.StringValue("Body"), types.StringNull(), true, ""},
+		{"body_file", types.StringNull(), types.StringValue("doc.md"), true, ""},
+		{"both", types.StringValue("Body"), types.StringValue("doc.md"), false, "Only one of"},
+		{"neither optional", types.StringNull(), types.StringNull(), false, ""},
+		{"neither required", types.StringNull(), types.StringNull(), true, "must be set"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateBodyConfig(tc.body, tc.bodyFile, tc.required, &diags)
+
+			if tc.err == "" && diags.HasError() {
+				t.Errorf("unexpected diagnostics: %v", diags)
+			}
+			if tc.err != "" && (!diags.HasError() || !strings.Contains(diags[0].Detail(), tc.err)) {
+				t.Errorf("expected an error containing '%s', got %v", tc.err, diags)
+			}
+		})
+	}
+}
+
+func TestChangelogResourceBodyFile(t *testing.T) {
+	defer gock.OffAll()
+
+	changelog := mockChangelogs[0]
+	dir := writeDocsDirectory(t, map[string]string{
+		"changelog.md": "---\ntitle: " + changelog.Title + "\n---\n" + changelog.Body + "\n",
+	})
+	file := filepath.ToSlash(filepath.Join(dir, "changelog.md"))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The title is read from the file front matter and only the hash is stored.
+			{
+				PreConfig: func() {
+					gock.OffAll()
+					gock.New(testURL).Post("/changelogs").Times(1).Reply(201).JSON(changelog)
+					gock.New(testURL).Get("/changelogs/" + changelog.Slug).Persist().Reply(200).JSON(changelog)
+					gock.New(testURL).Delete("/changelogs/" + changelog.Slug).Times(1).Reply(204)
+				},
+				Config: testProviderConfig + `
+					resource "readme_changelog" "test" {
+						body_file = "` + file + `"
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_changelog.test", "title", changelog.Title),
+					resource.TestCheckNoResourceAttr("readme_changelog.test", "body"),
+					resource.TestCheckNoResourceAttr("readme_changelog.test", "body_clean"),
+					resource.TestCheckResourceAttr(
+						"readme_changelog.test",
+						"body_hash",
+						bodyFileHash("---\ntitle: "+changelog.Title+"\n---\n"+changelog.Body+"\n"),
+					),
+				),
+			},
+			// body and body_file conflict.
+			{
+				Config: testProviderConfig + `
+					resource "readme_changelog" "test" {
+						body      = "Body"
+						body_file = "` + file + `"
+					}`,
+				ExpectError: regexp.MustCompile(`Only one of 'body' or 'body_file' may be set.`),
+			},
+		},
+	})
+}
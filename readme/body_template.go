@@ -0,0 +1,92 @@
+package readme
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/frontmatter"
+)
+
+// templateVarsDescription is the description of the `template_vars` attribute shared by the
+// changelog, custom page, and doc resources.
+const templateVarsDescription = "Variables to render the body with as a template. When `template_vars` or " +
+	"`snippet_dirs` is set, the body is rendered with Go template syntax before the front matter is read " +
+	"and the body is uploaded. Variables are referenced as `{{ .Name }}` and referencing a variable that " +
+	"isn't set is an error."
+
+// snippetDirsDescription is the description of the `snippet_dirs` attribute.
+const snippetDirsDescription = "Directories to search, in order, for snippets included in the body with " +
+	"`{{ include \"snippet.md\" }}`. Snippets are rendered with the same variables and may include other " +
+	"snippets. Snippet names must be relative paths within a directory. Setting this enables rendering " +
+	"the body as a template."
+
+// bodyRenderedDescription is the description of the `body_rendered` attribute.
+const bodyRenderedDescription = "The body rendered from the template, which is uploaded instead of the " +
+	"body. This is null when neither `template_vars` nor `snippet_dirs` is set."
+
+// errTemplateChanged is returned when the rendered body at apply doesn't match the plan.
+var errTemplateChanged = errors.New(
+	"the rendered body changed after the plan was created, such as when a snippet was modified; " +
+		"run the plan again to review the changes",
+)
+
+// planBodyRendered renders the body for the plan when templating is enabled.
+//
+// A null value is returned if templating isn't enabled and an unknown value is returned if the
+// body can't be rendered until apply.
+func planBodyRendered(
+	ctx context.Context,
+	body, bodyFile types.String,
+	vars types.Map,
+	snippetDirs types.List,
+	diags *diag.Diagnostics,
+) types.String {
+	if vars.IsNull() && snippetDirs.IsNull() {
+		return types.StringNull()
+	}
+
+	rendered, known, err := frontmatter.RenderBody(ctx, body, bodyFile, vars, snippetDirs)
+	if err != nil {
+		diags.AddAttributeError(path.Root("body"), "Unable to render body template.", err.Error())
+
+		return types.StringUnknown()
+	}
+
+	if !known {
+		return types.StringUnknown()
+	}
+
+	return types.StringValue(rendered)
+}
+
+// applyBodyTemplate renders the body returned by `applyBody` to send to the API and returns the
+// rendered body to save in the state.
+//
+// The rendered body must match the plan, if it was known, so the state reflects the contents that
+// were reviewed in the plan.
+func applyBodyTemplate(
+	ctx context.Context,
+	body string,
+	bodyFile types.String,
+	vars types.Map,
+	snippetDirs types.List,
+	planned types.String,
+) (string, types.String, error) {
+	if vars.IsNull() && snippetDirs.IsNull() {
+		return body, types.StringNull(), nil
+	}
+
+	rendered, _, err := frontmatter.RenderContent(ctx, body, bodyFile, vars, snippetDirs)
+	if err != nil {
+		return "", planned, err
+	}
+
+	if !planned.IsUnknown() && planned.ValueString() != rendered {
+		return "", planned, errTemplateChanged
+	}
+
+	return rendered, types.StringValue(rendered), nil
+}
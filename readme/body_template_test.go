@@ -0,0 +1,119 @@
+package readme
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestPlanBodyRendered(t *testing.T) {
+	ctx := context.Background()
+	dir := writeDocsDirectory(t, map[string]string{"note.md": "Use {{ .Product }}."})
+	vars := types.MapValueMust(types.StringType, map[string]attr.Value{"Product": types.StringValue("Widget")})
+	dirs := types.ListValueMust(types.StringType, []attr.Value{types.StringValue(dir)})
+	body := types.StringValue(`# {{ .Product }} {{ include "note.md" }}`)
+
+	var diags diag.Diagnostics
+
+	// Templating isn't enabled without variables or snippet directories.
+	rendered := planBodyRendered(ctx, body, types.StringNull(), types.MapNull(types.StringType),
+		types.ListNull(types.StringType), &diags)
+	if !rendered.IsNull() {
+		t.Errorf("expected a null rendered body, got %s", rendered)
+	}
+
+	rendered = planBodyRendered(ctx, body, types.StringNull(), vars, dirs, &diags)
+	if rendered.ValueString() != "# Widget Use Widget." {
+		t.Errorf("unexpected rendered body %s", rendered)
+	}
+
+	rendered = planBodyRendered(ctx, body, types.StringNull(), types.MapUnknown(types.StringType), dirs, &diags)
+	if !rendered.IsUnknown() {
+		t.Errorf("expected an unknown rendered body for unknown variables, got %s", rendered)
+	}
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	planBodyRendered(ctx, types.StringValue("{{ .Missing }}"), types.StringNull(), vars, dirs, &diags)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "body:1:3") {
+		t.Errorf("expected an error with the line of the template, got %v", diags)
+	}
+}
+
+func TestApplyBodyTemplate(t *testing.T) {
+	ctx := context.Background()
+	dir := writeDocsDirectory(t, map[string]string{"note.md": "Original"})
+	vars := types.MapNull(types.StringType)
+	dirs := types.ListValueMust(types.StringType, []attr.Value{types.StringValue(dir)})
+	body := `{{ include "note.md" }}`
+
+	rendered, value, err := applyBodyTemplate(ctx, body, types.StringNull(), types.MapNull(types.StringType),
+		types.ListNull(types.StringType), types.StringNull())
+	if err != nil || rendered != body || !value.IsNull() {
+		t.Errorf("expected the body without templating, got '%s', %s, %v", rendered, value, err)
+	}
+
+	rendered, value, err = applyBodyTemplate(ctx, body, types.StringNull(), vars, dirs, types.StringUnknown())
+	if err != nil || rendered != "Original" || value.ValueString() != "Original" {
+		t.Errorf("expected the rendered body, got '%s', %s, %v", rendered, value, err)
+	}
+
+	// The snippets must not change between the plan and apply.
+	if err := os.WriteFile(filepath.Join(dir, "note.md"), []byte("Changed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = applyBodyTemplate(ctx, body, types.StringNull(), vars, dirs, value)
+	if !errors.Is(err, errTemplateChanged) {
+		t.Errorf("expected an error for a changed snippet, got %v", err)
+	}
+}
+
+func TestChangelogResourceTemplate(t *testing.T) {
+	defer gock.OffAll()
+
+	changelog := mockChangelogs[0]
+	dir := filepath.ToSlash(writeDocsDirectory(t, map[string]string{"body.md": changelog.Body}))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The title is read from the rendered front matter and the rendered body is uploaded.
+			{
+				PreConfig: func() {
+					gock.OffAll()
+					gock.New(testURL).Post("/changelogs").
+						BodyString(`"title":"` + changelog.Title + `"`).
+						Times(1).Reply(201).JSON(changelog)
+					gock.New(testURL).Get("/changelogs/" + changelog.Slug).Persist().Reply(200).JSON(changelog)
+					gock.New(testURL).Delete("/changelogs/" + changelog.Slug).Times(1).Reply(204)
+				},
+				Config: testProviderConfig + `
+					resource "readme_changelog" "test" {
+						body          = "---\ntitle: {{ .Title }}\n---\n{{ include \"body.md\" }}"
+						template_vars = { Title = "` + changelog.Title + `" }
+						snippet_dirs  = ["` + dir + `"]
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_changelog.test", "title", changelog.Title),
+					resource.TestCheckResourceAttr(
+						"readme_changelog.test",
+						"body_rendered",
+						"---\ntitle: "+changelog.Title+"\n---\n"+changelog.Body,
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,136 @@
+// Package bodytemplate renders the Markdown body of changelogs, custom pages,
+// and docs as a template with variables and reusable snippet includes.
+//
+// Templates use Go's text/template syntax. Only the template variables and
+// the `include` function are available, so a template can't read files
+// outside of the snippet directories or run other functions.
+package bodytemplate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// maxIncludeDepth is the maximum depth of nested snippet includes, which prevents
+// snippets that include each other from rendering forever.
+const maxIncludeDepth = 10
+
+// Options configures rendering a body template.
+type Options struct {
+	// Vars are the variables available to the template as `{{ .Name }}`.
+	Vars map[string]string
+	// SnippetDirs are the directories searched in order for snippets included with
+	// `{{ include "name.md" }}`.
+	SnippetDirs []string
+}
+
+// Enabled returns true if variables or snippet directories are set. Bodies are only rendered as
+// templates when templating is enabled so existing bodies containing `{{` aren't changed.
+func (o Options) Enabled() bool {
+	return o.Vars != nil || o.SnippetDirs != nil
+}
+
+// Render renders a body template.
+//
+// The `name` parameter identifies the template in errors, such as the path of a body file.
+// Errors include the name and line of the template or snippet that failed to render.
+func Render(name, body string, opts Options) (string, error) {
+	r := renderer{opts: opts}
+
+	return r.render(name, body, 0)
+}
+
+// renderer renders a template and the snippets it includes.
+type renderer struct {
+	opts Options
+}
+
+// render renders a template at the specified include depth.
+func (r renderer) render(name, text string, depth int) (string, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"include": func(snippet string) (string, error) {
+				return r.include(snippet, depth+1)
+			},
+		}).
+		Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	vars := r.opts.Vars
+	if vars == nil {
+		vars = map[string]string{}
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, vars); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// include renders the first snippet found with the specified name in the snippet directories.
+func (r renderer) include(name string, depth int) (string, error) {
+	if depth > maxIncludeDepth {
+		return "", fmt.Errorf(
+			"snippet '%s' exceeds the maximum include depth of %d; check for snippets that include each other",
+			name, maxIncludeDepth,
+		)
+	}
+
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("snippet '%s' must be a relative path within a snippet directory", name)
+	}
+
+	for _, dir := range r.opts.SnippetDirs {
+		path := filepath.Join(dir, name)
+
+		content, err := readSnippet(dir, path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		return r.render(path, content, depth)
+	}
+
+	return "", fmt.Errorf(
+		"snippet '%s' was not found in the snippet directories: %s",
+		name, strings.Join(r.opts.SnippetDirs, ", "),
+	)
+}
+
+// readSnippet reads a snippet file, verifying that it doesn't resolve to a path outside of its
+// snippet directory through a symbolic link.
+func readSnippet(dir, path string) (string, error) {
+	resolvedDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+
+	if rel, err := filepath.Rel(resolvedDir, resolved); err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("snippet '%s' resolves to a path outside of the snippet directory %s", path, dir)
+	}
+
+	content, err := os.ReadFile(resolved)
+	if err != nil {
+		return "", fmt.Errorf("unable to read snippet: %w", err)
+	}
+
+	return string(content), nil
+}
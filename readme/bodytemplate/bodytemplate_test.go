@@ -0,0 +1,114 @@
+package bodytemplate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSnippets writes the snippet files to a temporary directory and returns its path.
+func writeSnippets(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestOptionsEnabled(t *testing.T) {
+	if (Options{}).Enabled() {
+		t.Error("expected empty options not to enable templating")
+	}
+
+	if !(Options{Vars: map[string]string{}}).Enabled() {
+		t.Error("expected variables to enable templating")
+	}
+
+	if !(Options{SnippetDirs: []string{}}).Enabled() {
+		t.Error("expected snippet directories to enable templating")
+	}
+}
+
+func TestRender(t *testing.T) {
+	shared := writeSnippets(t, map[string]string{
+		"install.md":       "Install {{ .Product }} with `{{ include \"cmd/install.md\" }}`.",
+		"cmd/install.md":   "brew install {{ .Package }}",
+		"override.md":      "shared",
+		"loop.md":          "{{ include \"loop.md\" }}",
+		"broken.md":        "first line\n{{ .Missing }}",
+		"unterminated.md":  "{{ .Product",
+		"nested/import.md": "{{ include \"override.md\" }}",
+	})
+	local := writeSnippets(t, map[string]string{"override.md": "local"})
+
+	opts := Options{
+		Vars:        map[string]string{"Product": "Widget", "Package": "widget"},
+		SnippetDirs: []string{local, shared},
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		expect string
+		err    string
+	}{
+		{"variables", "# {{ .Product }}", "# Widget", ""},
+		{"nested includes", `{{ include "install.md" }}`, "Install Widget with `brew install widget`.", ""},
+		{"first directory wins", `{{ include "override.md" }}`, "local", ""},
+		{"include from a subdirectory", `{{ include "nested/import.md" }}`, "local", ""},
+		{"missing variable", "line one\n{{ .Version }}", "", `doc.md:2:3: executing "doc.md" at <.Version>`},
+		{"missing variable in a snippet", `{{ include "broken.md" }}`, "", "broken.md:2:3"},
+		{"parse error in a snippet", `{{ include "unterminated.md" }}`, "", "unterminated.md:1"},
+		{"parse error", "{{ if }}", "", "doc.md:1"},
+		{"missing snippet", `{{ include "missing.md" }}`, "", "snippet 'missing.md' was not found"},
+		{"absolute snippet path", `{{ include "/etc/passwd" }}`, "", "must be a relative path"},
+		{"parent snippet path", `{{ include "../secret.md" }}`, "", "must be a relative path"},
+		{"recursive include", `{{ include "loop.md" }}`, "", "maximum include depth"},
+		{"no other functions", `{{ printf "%s" .Product }}{{ env "HOME" }}`, "", `function "env" not defined`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := Render("doc.md", tc.body, opts)
+
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if rendered != tc.expect {
+					t.Errorf("expected '%s', got '%s'", tc.expect, rendered)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("expected an error containing '%s', got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestRender_SymlinkOutsideSnippetDir(t *testing.T) {
+	outside := writeSnippets(t, map[string]string{"secret.md": "secret"})
+	dir := writeSnippets(t, map[string]string{})
+
+	if err := os.Symlink(filepath.Join(outside, "secret.md"), filepath.Join(dir, "link.md")); err != nil {
+		t.Skipf("unable to create a symbolic link: %s", err)
+	}
+
+	_, err := Render("doc.md", `{{ include "link.md" }}`, Options{SnippetDirs: []string{dir}})
+	if err == nil || !strings.Contains(err.Error(), "outside of the snippet directory") {
+		t.Errorf("expected an error for a snippet outside of the snippet directory, got %v", err)
+	}
+}
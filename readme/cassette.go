@@ -0,0 +1,255 @@
+package readme
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// envHTTPRecord is the environment variable that enables recording HTTP interactions to a
+	// cassette file.
+	envHTTPRecord = "README_HTTP_RECORD"
+
+	// envHTTPReplay is the environment variable that enables replaying HTTP interactions from a
+	// cassette file instead of making requests to the ReadMe API.
+	envHTTPReplay = "README_HTTP_REPLAY"
+
+	// redacted replaces sensitive values in recorded interactions.
+	redacted = "REDACTED"
+)
+
+// cassetteInteraction is a single recorded request and its response.
+//
+// A cassette file contains one JSON encoded interaction per line.
+type cassetteInteraction struct {
+	Request  cassetteRequest   `json:"request"`
+	Response *cassetteResponse `json:"response,omitempty"`
+	// Error is the error returned instead of a response, such as a network error.
+	Error string `json:"error,omitempty"`
+}
+
+// cassetteRequest is a recorded request.
+type cassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// cassetteResponse is a recorded response.
+type cassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// recordTransport is an http.RoundTripper that appends every request and response to a cassette
+// file. The API token is redacted from the recorded interactions.
+type recordTransport struct {
+	mu   sync.Mutex
+	path string
+	// secret is the value redacted from recorded interactions.
+	secret string
+	// next is the transport used to perform the request. http.DefaultTransport is used when nil.
+	next http.RoundTripper
+}
+
+// newRecordTransport returns a recordTransport that records interactions to the file at `path`.
+//
+// Interactions are appended to the file since Terraform may start the provider several times
+// during a single run.
+func newRecordTransport(path, secret string, next http.RoundTripper) *recordTransport {
+	return &recordTransport{path: path, secret: secret, next: next}
+}
+
+// RoundTrip performs the request and records it.
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	interaction := cassetteInteraction{
+		Request: cassetteRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: req.Header.Clone(),
+		},
+	}
+
+	if interaction.Request.Headers == nil {
+		interaction.Request.Headers = http.Header{}
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read request body for recording: %w", err)
+		}
+		interaction.Request.Body = string(data)
+
+		// Restore the body that was read so it can be sent.
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		interaction.Error = err.Error()
+	} else {
+		data, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if readErr != nil {
+			return nil, fmt.Errorf("unable to read response body for recording: %w", readErr)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+
+		interaction.Response = &cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header.Clone(),
+			Body:       string(data),
+		}
+	}
+
+	if recordErr := t.record(interaction); recordErr != nil {
+		return nil, recordErr
+	}
+
+	return resp, err //nolint:wrapcheck // The transport error is passed through.
+}
+
+// record redacts the interaction and appends it to the cassette file.
+func (t *recordTransport) record(interaction cassetteInteraction) error {
+	interaction.Request.Headers.Set("Authorization", redacted)
+	interaction.Request.URL = t.redact(interaction.Request.URL)
+	interaction.Request.Body = t.redact(interaction.Request.Body)
+	interaction.Error = t.redact(interaction.Error)
+	if interaction.Response != nil {
+		interaction.Response.Body = t.redact(interaction.Response.Body)
+	}
+
+	data, err := json.Marshal(interaction)
+	if err != nil {
+		return fmt.Errorf("unable to encode HTTP interaction for recording: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	file, err := os.OpenFile(t.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open HTTP recording file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("unable to write HTTP recording file: %w", err)
+	}
+
+	return nil
+}
+
+// redact replaces the secret in a recorded value.
+func (t *recordTransport) redact(value string) string {
+	if t.secret == "" {
+		return value
+	}
+
+	return strings.ReplaceAll(value, t.secret, redacted)
+}
+
+// replayTransport is an http.RoundTripper that responds to requests with the interactions from
+// a cassette file instead of making requests to the ReadMe API.
+//
+// Requests are matched by their method and URL. When the same request was recorded more than once,
+// the recorded responses are returned in order and the last one is repeated once they're used up.
+type replayTransport struct {
+	mu           sync.Mutex
+	interactions map[string][]cassetteInteraction
+}
+
+// newReplayTransport returns a replayTransport with the interactions loaded from the cassette file
+// at `path`.
+func newReplayTransport(path string) (*replayTransport, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open HTTP replay file: %w", err)
+	}
+	defer file.Close()
+
+	transport := &replayTransport{interactions: map[string][]cassetteInteraction{}}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var interaction cassetteInteraction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("unable to parse HTTP replay file %s on line %d: %w", path, line, err)
+		}
+
+		key := replayKey(interaction.Request.Method, interaction.Request.URL)
+		transport.interactions[key] = append(transport.interactions[key], interaction)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read HTTP replay file %s: %w", path, err)
+	}
+
+	return transport, nil
+}
+
+// replayKey returns the key used to match a request with its recorded interactions.
+func replayKey(method, url string) string {
+	return method + " " + url
+}
+
+// RoundTrip returns the recorded response for the request.
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	key := replayKey(req.Method, req.URL.String())
+
+	t.mu.Lock()
+	recorded := t.interactions[key]
+	if len(recorded) == 0 {
+		t.mu.Unlock()
+
+		return nil, fmt.Errorf("no recorded HTTP interaction for %s", key)
+	}
+	interaction := recorded[0]
+	if len(recorded) > 1 {
+		t.interactions[key] = recorded[1:]
+	}
+	t.mu.Unlock()
+
+	if interaction.Response == nil {
+		return nil, errors.New(interaction.Error)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Response.Headers.Clone(),
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package readme
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCassette_RecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+	next := &fakeTransport{
+		responses: []*http.Response{
+			fakeResponse(200, map[string]string{"Content-Type": "application/json"}),
+			{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"title":"updated"}`))},
+			nil,
+		},
+		errs: []error{nil, nil, errors.New("connection reset")},
+	}
+	recorder := newRecordTransport(path, testToken, next)
+
+	requests := []*http.Request{}
+	for _, body := range []string{"", `{"token":"` + testToken + `"}`, ""} {
+		req, _ := http.NewRequest(http.MethodGet, testURL+"/docs/test", nil)
+		if body != "" {
+			req, _ = http.NewRequest(http.MethodPut, testURL+"/docs/test", strings.NewReader(body))
+		}
+		req.SetBasicAuth(testToken, "")
+		requests = append(requests, req)
+	}
+
+	for i, req := range requests {
+		resp, err := recorder.RoundTrip(req)
+		if i == 2 {
+			if err == nil {
+				t.Fatal("expected the transport error to be returned")
+			}
+
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		// The response body must still be readable after it's recorded.
+		if body, _ := io.ReadAll(resp.Body); len(body) == 0 {
+			t.Errorf("request %d: expected a response body", i+1)
+		}
+	}
+
+	// The request body must still be sent after it's recorded.
+	if next.bodies[1] != `{"token":"`+testToken+`"}` {
+		t.Errorf("unexpected request body sent: %s", next.bodies[1])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read cassette: %s", err)
+	}
+	if strings.Contains(string(data), testToken) {
+		t.Errorf("expected the API token to be redacted from the cassette:\n%s", data)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 3 {
+		t.Errorf("expected 3 recorded interactions, got %d", lines)
+	}
+
+	replay, err := newReplayTransport(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading cassette: %s", err)
+	}
+
+	// Responses to the same request are returned in the order they were recorded.
+	for i, expected := range []string{"{}", "{}"} {
+		req, _ := http.NewRequest(http.MethodGet, testURL+"/docs/test", nil)
+		resp, err := replay.RoundTrip(req)
+		if i == 0 && err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if i == 0 {
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != expected || resp.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("unexpected replayed response: %s", body)
+			}
+
+			continue
+		}
+		if err == nil || err.Error() != "connection reset" {
+			t.Errorf("expected the recorded error to be replayed, got %v", err)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, testURL+"/docs/test", strings.NewReader("{}"))
+	resp, err := replay.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != `{"title":"updated"}` {
+		t.Errorf("unexpected replayed response: %s", body)
+	}
+
+	req, _ = http.NewRequest(http.MethodDelete, testURL+"/docs/test", nil)
+	if _, err := replay.RoundTrip(req); err == nil {
+		t.Error("expected an error for a request that wasn't recorded")
+	}
+}
+
+func TestCassette_ReplayInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	if err := os.WriteFile(path, []byte("not json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newReplayTransport(path); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected a parse error for line 1, got %v", err)
+	}
+
+	if _, err := newReplayTransport(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
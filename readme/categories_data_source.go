@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/liveoaklabs/readme-api-go-client/readme"
 )
@@ -94,10 +95,12 @@ func (d *categoriesDataSource) Read(
 	// Get categories metadata from ReadMe API.
 	categories, apiResponse, err := d.client.Category.GetAll()
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(clientDiagnostic(
 			"Unable to retrieve categories metadata.",
-			clientError(err, apiResponse),
-		)
+			err,
+			apiResponse,
+			apiErrorPaths{"VERSION_NOTFOUND": path.Empty()},
+		))
 
 		return
 	}
@@ -141,5 +144,5 @@ func (d *categoriesDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
@@ -17,7 +17,8 @@ var (
 
 // categoryDataSource is the data source implementation.
 type categoryDataSource struct {
-	client *readme.Client
+	client  *readme.Client
+	lookups *lookupCache
 }
 
 // categoryModel maps an API specification to the apiSpecification schema data.
@@ -146,10 +147,12 @@ func (d *categoryDataSource) Read(
 	// Get category metadata from ReadMe API
 	category, apiResponse, err := d.client.Category.Get(state.Slug.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(clientDiagnostic(
 			"Unable to retrieve category metadata.",
-			clientError(err, apiResponse),
-		)
+			err,
+			apiResponse,
+			categoryErrorPaths,
+		))
 
 		return
 	}
@@ -165,7 +168,7 @@ func (d *categoryDataSource) Read(
 		Slug:         types.StringValue(category.Slug),
 		Title:        types.StringValue(category.Title),
 		Type:         types.StringValue(category.Type),
-		Version:      types.StringValue(versionClean(ctx, d.client, category.Version)),
+		Version:      types.StringValue(d.lookups.versionClean(ctx, d.client, category.Version)),
 		VersionID:    types.StringValue(category.Version),
 	}
 
@@ -187,5 +190,7 @@ func (d *categoryDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	cfg := req.ProviderData.(*providerData)
+	d.client = cfg.client
+	d.lookups = cfg.lookups
 }
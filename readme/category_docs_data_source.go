@@ -2,19 +2,29 @@ package readme
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/liveoaklabs/readme-api-go-client/readme"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &categoryDocsDataSource{}
-	_ datasource.DataSourceWithConfigure = &categoryDocsDataSource{}
+	_ datasource.DataSource                   = &categoryDocsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &categoryDocsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &categoryDocsDataSource{}
 )
 
+// categoryDocsBodyConcurrency is the default number of doc bodies retrieved at once.
+const categoryDocsBodyConcurrency = 4
+
 // categoryDocsDataSource is the data source implementation.
 type categoryDocsDataSource struct {
 	client *readme.Client
@@ -22,9 +32,14 @@ type categoryDocsDataSource struct {
 
 // categoryDocsModel maps the response to the Terraform data source schema.
 type categoryDocsModel struct {
-	ID   types.String  `tfsdk:"id"`
-	Slug types.String  `tfsdk:"slug"`
-	Docs []categoryDoc `tfsdk:"docs"`
+	ID              types.String      `tfsdk:"id"`
+	Slug            types.String      `tfsdk:"slug"`
+	BodyConcurrency types.Int64       `tfsdk:"body_concurrency"`
+	Docs            []categoryDoc     `tfsdk:"docs"`
+	Flat            []categoryDocFlat `tfsdk:"flat"`
+	IncludeBody     types.Bool        `tfsdk:"include_body"`
+	IncludeHidden   types.Bool        `tfsdk:"include_hidden"`
+	MaxDepth        types.Int64       `tfsdk:"max_depth"`
 }
 
 // categoryDocs represents a document within a category.
@@ -37,13 +52,29 @@ type categoryDoc struct {
 	Children []categoryDocChild `tfsdk:"children"`
 }
 
-// categoryDocsChild represents a child document within a category.
+// categoryDocsChild represents a child document at any depth beneath a document within a category.
 type categoryDocChild struct {
-	ID     types.String `tfsdk:"id"`
-	Title  types.String `tfsdk:"title"`
-	Slug   types.String `tfsdk:"slug"`
-	Order  types.Int64  `tfsdk:"order"`
-	Hidden types.Bool   `tfsdk:"hidden"`
+	ID         types.String `tfsdk:"id"`
+	Title      types.String `tfsdk:"title"`
+	Slug       types.String `tfsdk:"slug"`
+	Order      types.Int64  `tfsdk:"order"`
+	Hidden     types.Bool   `tfsdk:"hidden"`
+	ParentSlug types.String `tfsdk:"parent_slug"`
+	Depth      types.Int64  `tfsdk:"depth"`
+}
+
+// categoryDocFlat represents a doc at any depth in the flattened list of a category's docs.
+type categoryDocFlat struct {
+	ID         types.String `tfsdk:"id"`
+	Title      types.String `tfsdk:"title"`
+	Slug       types.String `tfsdk:"slug"`
+	Order      types.Int64  `tfsdk:"order"`
+	Hidden     types.Bool   `tfsdk:"hidden"`
+	Body       types.String `tfsdk:"body"`
+	Depth      types.Int64  `tfsdk:"depth"`
+	ParentSlug types.String `tfsdk:"parent_slug"`
+	Path       types.String `tfsdk:"path"`
+	Position   types.Int64  `tfsdk:"position"`
 }
 
 // NewCategoryDocsDataSource is a helper function to simplify the provider implementation.
@@ -68,6 +99,8 @@ func (d *categoryDocsDataSource) Schema(
 ) {
 	resp.Schema = schema.Schema{
 		Description: "Retrieve all docs for a project category on ReadMe.com\n\n" +
+			"The docs are returned as a tree in `docs` and as a flat list in `flat`, which is easier to " +
+			"iterate over with `for` expressions. Both are filtered by `include_hidden` and `max_depth`.\n\n" +
 			"See <https://docs.readme.com/main/reference/getcategorydocs> for more information about this API endpoint.\n\n",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -78,6 +111,76 @@ func (d *categoryDocsDataSource) Schema(
 				Description: "The category slug to retrieve docs for.",
 				Required:    true,
 			},
+			"body_concurrency": schema.Int64Attribute{
+				Description: fmt.Sprintf("The most doc bodies to retrieve at once when `include_body` is "+
+					"`true`. Defaults to %d.", categoryDocsBodyConcurrency),
+				Optional: true,
+			},
+			"include_body": schema.BoolAttribute{
+				Description: "Retrieve the body of each doc in `flat`. Each body is a separate request. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"include_hidden": schema.BoolAttribute{
+				Description: "Include hidden docs. The children of a hidden doc are left out when this is " +
+					"`false`. Defaults to `true`.",
+				Optional: true,
+			},
+			"max_depth": schema.Int64Attribute{
+				Description: "The deepest docs to include. Top level docs have a depth of 0, so `0` leaves out " +
+					"all child docs. Defaults to no limit.",
+				Optional: true,
+			},
+			"flat": schema.ListNestedAttribute{
+				Description: "The docs at any depth as a flat list. Each doc is listed before its children, " +
+					"in the order they're returned by the API.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"slug": schema.StringAttribute{
+							Description: "The slug of the doc.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "The title of the doc.",
+							Computed:    true,
+						},
+						"order": schema.Int64Attribute{
+							Description: "The order of the doc.",
+							Computed:    true,
+						},
+						"hidden": schema.BoolAttribute{
+							Description: "Whether the doc is hidden.",
+							Computed:    true,
+						},
+						"id": schema.StringAttribute{
+							Description: "The unique ID of the doc.",
+							Computed:    true,
+						},
+						"body": schema.StringAttribute{
+							Description: "The body of the doc when `include_body` is `true`.",
+							Computed:    true,
+						},
+						"depth": schema.Int64Attribute{
+							Description: "The number of ancestors of the doc. Top level docs have a depth of 0.",
+							Computed:    true,
+						},
+						"parent_slug": schema.StringAttribute{
+							Description: "The slug of the doc's parent. This is empty for top level docs.",
+							Computed:    true,
+						},
+						"path": schema.StringAttribute{
+							Description: "The slugs of the doc's ancestors and the doc separated by `/`, such as " +
+								"`parent/child`.",
+							Computed: true,
+						},
+						"position": schema.Int64Attribute{
+							Description: "The position of the doc among its siblings, starting at 0.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 			"docs": schema.ListNestedAttribute{
 				Description: "List of category summaries.",
 				Computed:    true,
@@ -104,8 +207,9 @@ func (d *categoryDocsDataSource) Schema(
 							Computed:    true,
 						},
 						"children": schema.ListNestedAttribute{
-							Description: "",
-							Computed:    true,
+							Description: "The child docs at any depth beneath the doc. Each child is listed " +
+								"before its own children.",
+							Computed: true,
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									"slug": schema.StringAttribute{
@@ -128,6 +232,15 @@ func (d *categoryDocsDataSource) Schema(
 										Description: "The unique ID of the category.",
 										Computed:    true,
 									},
+									"parent_slug": schema.StringAttribute{
+										Description: "The slug of the child doc's parent.",
+										Computed:    true,
+									},
+									"depth": schema.Int64Attribute{
+										Description: "The depth of the child doc beneath the doc. Direct " +
+											"children have a depth of 1.",
+										Computed: true,
+									},
 								},
 							},
 						},
@@ -156,38 +269,74 @@ func (d *categoryDocsDataSource) Read(
 	// Get categoryDocs metadata from ReadMe API.
 	categoryDocs, apiResponse, err := d.client.Category.GetDocs(state.Slug.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(clientDiagnostic(
 			"Unable to retrieve category docs.",
-			clientError(err, apiResponse),
-		)
+			err,
+			apiResponse,
+			apiErrorPaths{"CATEGORY_NOTFOUND": path.Root("slug"), "VERSION_NOTFOUND": path.Empty()},
+		))
 
 		return
 	}
 
-	for _, catDoc := range categoryDocs {
-		// Map response body to model.
-		doc := categoryDoc{
-			ID:       types.StringValue(catDoc.ID),
-			Order:    types.Int64Value(int64(catDoc.Order)),
-			Slug:     types.StringValue(catDoc.Slug),
-			Title:    types.StringValue(catDoc.Title),
-			Hidden:   types.BoolValue(catDoc.Hidden),
-			Children: []categoryDocChild{},
-		}
+	maxDepth := -1
+	if !state.MaxDepth.IsNull() {
+		maxDepth = int(state.MaxDepth.ValueInt64())
+	}
 
-		// Map children doc list to Terraform type.
-		for _, childDoc := range catDoc.Children {
-			child := categoryDocChild{
-				ID:     types.StringValue(childDoc.ID),
-				Order:  types.Int64Value(int64(childDoc.Order)),
-				Slug:   types.StringValue(childDoc.Slug),
-				Title:  types.StringValue(childDoc.Title),
-				Hidden: types.BoolValue(childDoc.Hidden),
-			}
-			doc.Children = append(doc.Children, child)
+	nodes := filterCategoryDocNodes(
+		categoryDocNodes(categoryDocs),
+		state.IncludeHidden.IsNull() || state.IncludeHidden.ValueBool(),
+		maxDepth,
+	)
+
+	state.Flat = []categoryDocFlat{}
+	for _, node := range nodes {
+		state.Flat = append(state.Flat, categoryDocFlat{
+			ID:         types.StringValue(node.doc.ID),
+			Order:      types.Int64Value(int64(node.doc.Order)),
+			Slug:       types.StringValue(node.doc.Slug),
+			Title:      types.StringValue(node.doc.Title),
+			Hidden:     types.BoolValue(node.doc.Hidden),
+			Body:       types.StringNull(),
+			Depth:      types.Int64Value(int64(node.depth)),
+			ParentSlug: types.StringValue(node.parentSlug),
+			Path:       types.StringValue(node.path),
+			Position:   types.Int64Value(int64(node.position)),
+		})
+
+		// Map the top level docs with their children at any depth to the Terraform type. Each
+		// doc is walked before its children, so a child belongs to the last top level doc.
+		if node.depth == 0 {
+			state.Docs = append(state.Docs, categoryDoc{
+				ID:       types.StringValue(node.doc.ID),
+				Order:    types.Int64Value(int64(node.doc.Order)),
+				Slug:     types.StringValue(node.doc.Slug),
+				Title:    types.StringValue(node.doc.Title),
+				Hidden:   types.BoolValue(node.doc.Hidden),
+				Children: []categoryDocChild{},
+			})
+
+			continue
 		}
 
-		state.Docs = append(state.Docs, doc)
+		doc := &state.Docs[len(state.Docs)-1]
+		doc.Children = append(doc.Children, categoryDocChild{
+			ID:         types.StringValue(node.doc.ID),
+			Order:      types.Int64Value(int64(node.doc.Order)),
+			Slug:       types.StringValue(node.doc.Slug),
+			Title:      types.StringValue(node.doc.Title),
+			Hidden:     types.BoolValue(node.doc.Hidden),
+			ParentSlug: types.StringValue(node.parentSlug),
+			Depth:      types.Int64Value(int64(node.depth)),
+		})
+	}
+
+	if state.IncludeBody.ValueBool() {
+		d.readBodies(ctx, &state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	state.ID = types.StringValue("readme_category_docs")
@@ -200,6 +349,72 @@ func (d *categoryDocsDataSource) Read(
 	}
 }
 
+// readBodies retrieves the body of each doc in the flat list using a bounded number of concurrent
+// requests.
+func (d *categoryDocsDataSource) readBodies(ctx context.Context, state *categoryDocsModel, diags *diag.Diagnostics) {
+	workers := categoryDocsBodyConcurrency
+	if !state.BodyConcurrency.IsNull() {
+		workers = int(state.BodyConcurrency.ValueInt64())
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("retrieving the body of %d docs with %d workers", len(state.Flat), workers))
+
+	bodies := make([]string, len(state.Flat))
+	errs := forEachConcurrent(ctx, len(state.Flat), workers, func(i int) error {
+		doc, apiResponse, err := d.client.Doc.Get(state.Flat[i].Slug.ValueString())
+		if err != nil {
+			return errors.New(clientError(err, apiResponse))
+		}
+
+		bodies[i] = strings.ReplaceAll(strings.TrimSpace(doc.Body), `\n`, "\n")
+
+		return nil
+	})
+
+	for i, err := range errs {
+		if err != nil {
+			diags.AddError(
+				"Unable to retrieve doc body.",
+				fmt.Sprintf("Unable to retrieve the body of doc %s: %s", state.Flat[i].Slug.ValueString(), err),
+			)
+
+			continue
+		}
+
+		state.Flat[i].Body = types.StringValue(bodies[i])
+	}
+}
+
+// ValidateConfig checks the filter and concurrency values.
+func (d *categoryDocsDataSource) ValidateConfig(
+	ctx context.Context,
+	req datasource.ValidateConfigRequest,
+	resp *datasource.ValidateConfigResponse,
+) {
+	var config categoryDocsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.MaxDepth.IsNull() && !config.MaxDepth.IsUnknown() && config.MaxDepth.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_depth"),
+			"Invalid maximum depth.",
+			"max_depth must be at least 0. Remove the attribute to include docs at any depth.",
+		)
+	}
+
+	if !config.BodyConcurrency.IsNull() && !config.BodyConcurrency.IsUnknown() &&
+		config.BodyConcurrency.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("body_concurrency"),
+			"Invalid body concurrency.",
+			"body_concurrency must be at least 1.",
+		)
+	}
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *categoryDocsDataSource) Configure(
 	ctx context.Context,
@@ -210,5 +425,5 @@ func (d *categoryDocsDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
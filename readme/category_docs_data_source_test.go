@@ -25,6 +25,15 @@ func TestCategoryDocsDataSource(t *testing.T) {
 					Slug:   "test-child-doc",
 					Order:  999,
 					Hidden: false,
+					Children: []readme.CategoryDocs{
+						{
+							ID:     "63bdfb0079110f009464178a",
+							Title:  "Test Grandchild Doc",
+							Slug:   "test-grandchild-doc",
+							Order:  999,
+							Hidden: true,
+						},
+					},
 				},
 			},
 		},
@@ -96,8 +105,112 @@ func TestCategoryDocsDataSource(t *testing.T) {
 						"docs.0.children.0.hidden",
 						"false",
 					),
+					resource.TestCheckResourceAttr(
+						"data.readme_category_docs.test",
+						"docs.0.children.0.depth",
+						"1",
+					),
+					// Docs nested beneath the children are included.
+					resource.TestCheckResourceAttr(
+						"data.readme_category_docs.test",
+						"docs.0.children.1.slug",
+						"test-grandchild-doc",
+					),
+					resource.TestCheckResourceAttr(
+						"data.readme_category_docs.test",
+						"docs.0.children.1.parent_slug",
+						"test-child-doc",
+					),
+					resource.TestCheckResourceAttr(
+						"data.readme_category_docs.test",
+						"docs.0.children.1.depth",
+						"2",
+					),
+					// All of the docs are in the flat list.
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.#", "3"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.0.depth", "0"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.0.parent_slug", ""),
+					resource.TestCheckResourceAttr(
+						"data.readme_category_docs.test",
+						"flat.2.path",
+						"getting-started/test-child-doc/test-grandchild-doc",
+					),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.2.depth", "2"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.2.position", "0"),
+					resource.TestCheckNoResourceAttr("data.readme_category_docs.test", "flat.2.body"),
+				),
+			},
+		},
+	})
+}
+
+func TestCategoryDocsDataSource_Filters(t *testing.T) {
+	expectResponse := []readme.CategoryDocs{
+		{ID: "1", Title: "First", Slug: "first", Children: []readme.CategoryDocs{
+			{ID: "2", Title: "Hidden", Slug: "hidden", Hidden: true, Children: []readme.CategoryDocs{
+				{ID: "3", Title: "Under Hidden", Slug: "under-hidden"},
+			}},
+			{ID: "4", Title: "Child", Slug: "child", Children: []readme.CategoryDocs{
+				{ID: "5", Title: "Grandchild", Slug: "grandchild"},
+			}},
+		}},
+		{ID: "6", Title: "Second", Slug: "second"},
+	}
+
+	// Close all gocks when completed.
+	defer gock.OffAll()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					gock.OffAll()
+					gock.New(testURL).
+						Get("/categories/testing/docs").
+						Persist().
+						Reply(200).
+						JSON(expectResponse)
+					for _, slug := range []string{"first", "child", "second"} {
+						gock.New(testURL).
+							Get("/docs/" + slug).
+							Persist().
+							Reply(200).
+							JSON(readme.Doc{Slug: slug, Body: "The " + slug + " doc."})
+					}
+				},
+				Config: testProviderConfig + `
+					data "readme_category_docs" "test" {
+						slug             = "testing"
+						include_hidden   = false
+						max_depth        = 1
+						include_body     = true
+						body_concurrency = 2
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.#", "3"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.0.slug", "first"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.0.body", "The first doc."),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.1.slug", "child"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.1.path", "first/child"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.1.parent_slug", "first"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.1.position", "1"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.1.body", "The child doc."),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.2.slug", "second"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "flat.2.position", "1"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "docs.#", "2"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "docs.0.children.#", "1"),
+					resource.TestCheckResourceAttr("data.readme_category_docs.test", "docs.0.children.0.slug", "child"),
 				),
 			},
+			{
+				Config: testProviderConfig + `
+					data "readme_category_docs" "test" {
+						slug      = "testing"
+						max_depth = -1
+					}`,
+				ExpectError: regexp.MustCompile(`max_depth must be at least 0`),
+			},
 		},
 	})
 }
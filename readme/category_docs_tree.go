@@ -0,0 +1,148 @@
+package readme
+
+import (
+	"sort"
+
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+// categoryDocNode is a doc in a category's doc tree along with its position in the tree.
+type categoryDocNode struct {
+	doc readme.CategoryDocs
+	// parentSlug is the slug of the doc's parent. It's empty for top-level docs.
+	parentSlug string
+	// depth is the number of ancestors the doc has. Top-level docs have a depth of 0.
+	depth int
+	// path is the slugs of the doc's ancestors and the doc joined with slashes, such as
+	// `parent/child`.
+	path string
+	// position is the index of the doc among its siblings, starting at 0.
+	position int
+}
+
+// walkCategoryDocs calls fn for each doc in a category's doc tree at any depth.
+//
+// Docs are visited in the order they're returned by the API, with each doc visited before its
+// children. The walk stops when fn returns false.
+func walkCategoryDocs(docs []readme.CategoryDocs, fn func(node categoryDocNode) bool) {
+	walkCategoryDocNodes(docs, "", "", 0, fn)
+}
+
+// walkCategoryDocNodes walks the docs at a single level of the tree and returns false if the walk
+// was stopped.
+func walkCategoryDocNodes(
+	docs []readme.CategoryDocs,
+	parentSlug string,
+	parentPath string,
+	depth int,
+	fn func(node categoryDocNode) bool,
+) bool {
+	for i, doc := range docs {
+		docPath := doc.Slug
+		if parentPath != "" {
+			docPath = parentPath + "/" + doc.Slug
+		}
+
+		node := categoryDocNode{doc: doc, parentSlug: parentSlug, depth: depth, path: docPath, position: i}
+		if !fn(node) {
+			return false
+		}
+
+		if !walkCategoryDocNodes(doc.Children, doc.Slug, docPath, depth+1, fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// categoryDocNodes returns every doc in a category's doc tree in the order they're walked.
+func categoryDocNodes(docs []readme.CategoryDocs) []categoryDocNode {
+	nodes := []categoryDocNode{}
+	walkCategoryDocs(docs, func(node categoryDocNode) bool {
+		nodes = append(nodes, node)
+
+		return true
+	})
+
+	return nodes
+}
+
+// filterCategoryDocNodes returns the nodes that aren't hidden, unless `includeHidden` is true, and
+// that are no deeper than `maxDepth`, unless it's negative.
+//
+// The nodes must be in walk order. The descendants of a doc that's left out are also left out.
+func filterCategoryDocNodes(nodes []categoryDocNode, includeHidden bool, maxDepth int) []categoryDocNode {
+	filtered := []categoryDocNode{}
+	excluded := map[string]bool{}
+
+	for _, node := range nodes {
+		if excluded[docParentPath(node)] ||
+			(!includeHidden && node.doc.Hidden) ||
+			(maxDepth >= 0 && node.depth > maxDepth) {
+			excluded[node.path] = true
+
+			continue
+		}
+
+		filtered = append(filtered, node)
+	}
+
+	return filtered
+}
+
+// findCategoryDoc returns the first doc in a category's doc tree with the specified ID or slug.
+//
+// An empty ID or slug never matches.
+func findCategoryDoc(docs []readme.CategoryDocs, id, slug string) (categoryDocNode, bool) {
+	var found categoryDocNode
+	ok := false
+
+	walkCategoryDocs(docs, func(node categoryDocNode) bool {
+		if (id != "" && node.doc.ID == id) || (slug != "" && node.doc.Slug == slug) {
+			found = node
+			ok = true
+
+			return false
+		}
+
+		return true
+	})
+
+	return found, ok
+}
+
+// categoryDocDescendants returns the descendants of a doc at any depth, with each doc listed
+// before its children.
+//
+// The depth and path of each descendant are relative to the doc, so its children have a depth of 1
+// and a path that starts with the doc's slug.
+func categoryDocDescendants(doc readme.CategoryDocs) []categoryDocNode {
+	descendants := []categoryDocNode{}
+	walkCategoryDocNodes(doc.Children, doc.Slug, doc.Slug, 1, func(node categoryDocNode) bool {
+		descendants = append(descendants, node)
+
+		return true
+	})
+
+	return descendants
+}
+
+// categoryDocDeleteOrder returns the slugs of a doc's descendants in the order they can be safely
+// deleted.
+//
+// The deepest descendants are first so that no doc is deleted while it still has children. Docs
+// at the same depth keep the order they're returned by the API.
+func categoryDocDeleteOrder(doc readme.CategoryDocs) []string {
+	descendants := categoryDocDescendants(doc)
+	sort.SliceStable(descendants, func(i, j int) bool {
+		return descendants[i].depth > descendants[j].depth
+	})
+
+	slugs := make([]string, 0, len(descendants))
+	for _, node := range descendants {
+		slugs = append(slugs, node.doc.Slug)
+	}
+
+	return slugs
+}
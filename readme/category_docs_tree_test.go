@@ -0,0 +1,186 @@
+package readme
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+// mockDeepCategoryDocs is a category doc tree nested deeper than the parent, child, and grandchild
+// levels.
+var mockDeepCategoryDocs = []readme.CategoryDocs{
+	{ID: "1", Slug: "top", Children: []readme.CategoryDocs{
+		{ID: "2", Slug: "first-child", Children: []readme.CategoryDocs{
+			{ID: "3", Slug: "grandchild", Children: []readme.CategoryDocs{
+				{ID: "4", Slug: "great-grandchild", Children: []readme.CategoryDocs{
+					{ID: "5", Slug: "great-great-grandchild"},
+				}},
+			}},
+		}},
+		{ID: "6", Slug: "second-child"},
+	}},
+	{ID: "7", Slug: "other"},
+}
+
+func TestWalkCategoryDocs(t *testing.T) {
+	visited := []string{}
+	walkCategoryDocs(mockDeepCategoryDocs, func(node categoryDocNode) bool {
+		visited = append(visited, node.parentSlug+">"+node.doc.Slug)
+
+		return true
+	})
+
+	expect := []string{
+		">top",
+		"top>first-child",
+		"first-child>grandchild",
+		"grandchild>great-grandchild",
+		"great-grandchild>great-great-grandchild",
+		"top>second-child",
+		">other",
+	}
+	if !reflect.DeepEqual(visited, expect) {
+		t.Errorf("expected the walk order %v, got %v", expect, visited)
+	}
+
+	// The walk stops when the function returns false.
+	count := 0
+	walkCategoryDocs(mockDeepCategoryDocs, func(node categoryDocNode) bool {
+		count++
+
+		return node.doc.Slug != "grandchild"
+	})
+	if count != 3 {
+		t.Errorf("expected the walk to stop after 3 docs, visited %d", count)
+	}
+}
+
+func TestFindCategoryDoc(t *testing.T) {
+	node, ok := findCategoryDoc(mockDeepCategoryDocs, "", "great-great-grandchild")
+	if !ok || node.doc.ID != "5" || node.depth != 4 || node.parentSlug != "great-grandchild" {
+		t.Errorf("expected to find the doc by slug at depth 4, got %+v", node)
+	}
+	if expect := "top/first-child/grandchild/great-grandchild/great-great-grandchild"; node.path != expect {
+		t.Errorf("expected the path %s, got %s", expect, node.path)
+	}
+
+	node, ok = findCategoryDoc(mockDeepCategoryDocs, "4", "")
+	if !ok || node.doc.Slug != "great-grandchild" {
+		t.Errorf("expected to find the doc by ID, got %+v", node)
+	}
+
+	if _, ok := findCategoryDoc(mockDeepCategoryDocs, "", ""); ok {
+		t.Error("expected an empty ID and slug not to match")
+	}
+
+	if _, ok := findCategoryDoc(mockDeepCategoryDocs, "missing", "missing"); ok {
+		t.Error("expected a missing doc not to match")
+	}
+}
+
+func TestFilterCategoryDocNodes(t *testing.T) {
+	docs := []readme.CategoryDocs{
+		{Slug: "top", Children: []readme.CategoryDocs{
+			{Slug: "hidden", Hidden: true, Children: []readme.CategoryDocs{
+				{Slug: "under-hidden"},
+			}},
+			{Slug: "child", Children: []readme.CategoryDocs{
+				{Slug: "grandchild"},
+			}},
+		}},
+	}
+
+	tests := []struct {
+		name          string
+		includeHidden bool
+		maxDepth      int
+		expect        []string
+	}{
+		{"everything", true, -1, []string{"top", "hidden", "under-hidden", "child", "grandchild"}},
+		{"without hidden docs", false, -1, []string{"top", "child", "grandchild"}},
+		{"top level", true, 0, []string{"top"}},
+		{"children", false, 1, []string{"top", "child"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			slugs := []string{}
+			for _, node := range filterCategoryDocNodes(categoryDocNodes(docs), tc.includeHidden, tc.maxDepth) {
+				slugs = append(slugs, node.doc.Slug)
+			}
+
+			if !reflect.DeepEqual(slugs, tc.expect) {
+				t.Errorf("expected %v, got %v", tc.expect, slugs)
+			}
+		})
+	}
+}
+
+func TestCategoryDocDescendants(t *testing.T) {
+	descendants := categoryDocDescendants(mockDeepCategoryDocs[0])
+
+	slugs := []string{}
+	depths := []int{}
+	for _, node := range descendants {
+		slugs = append(slugs, node.doc.Slug)
+		depths = append(depths, node.depth)
+	}
+
+	expectSlugs := []string{
+		"first-child", "grandchild", "great-grandchild", "great-great-grandchild", "second-child",
+	}
+	if !reflect.DeepEqual(slugs, expectSlugs) {
+		t.Errorf("expected the descendants %v, got %v", expectSlugs, slugs)
+	}
+	if !reflect.DeepEqual(depths, []int{1, 2, 3, 4, 1}) {
+		t.Errorf("unexpected descendant depths %v", depths)
+	}
+
+	if descendants := categoryDocDescendants(mockDeepCategoryDocs[1]); len(descendants) != 0 {
+		t.Errorf("expected no descendants, got %v", descendants)
+	}
+}
+
+func TestCategoryDocDeleteOrder(t *testing.T) {
+	order := categoryDocDeleteOrder(mockDeepCategoryDocs[0])
+	expect := []string{
+		"great-great-grandchild", "great-grandchild", "grandchild", "first-child", "second-child",
+	}
+	if !reflect.DeepEqual(order, expect) {
+		t.Errorf("expected the delete order %v, got %v", expect, order)
+	}
+}
+
+func TestDocParentNotDescendant(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     string
+		parent string
+		err    string
+	}{
+		{"unrelated parent", "6", "7", ""},
+		{"parent of the doc", "3", "2", ""},
+		{"doc not in the tree", "", "4", ""},
+		{"own parent", "2", "2", "can't be its own parent"},
+		{"child as parent", "2", "5", "is a child of the doc 'first-child'"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parent, ok := findCategoryDoc(mockDeepCategoryDocs, tc.parent, "")
+			if !ok {
+				t.Fatalf("parent %s not found", tc.parent)
+			}
+
+			valid, detail := docParentNotDescendant(mockDeepCategoryDocs, tc.id, parent)
+			if tc.err == "" && !valid {
+				t.Errorf("expected the parent to be valid, got '%s'", detail)
+			}
+			if tc.err != "" && (valid || !strings.Contains(detail, tc.err)) {
+				t.Errorf("expected an error containing '%s', got '%s'", tc.err, detail)
+			}
+		})
+	}
+}
@@ -22,12 +22,17 @@ var (
 	_ resource.Resource                = &categoryResource{}
 	_ resource.ResourceWithConfigure   = &categoryResource{}
 	_ resource.ResourceWithImportState = &categoryResource{}
+	_ resource.ResourceWithModifyPlan  = &categoryResource{}
 )
 
+// categoryErrorPaths scopes API errors for a category to its `slug` attribute.
+var categoryErrorPaths = apiErrorPaths{"CATEGORY_NOTFOUND": path.Root("slug")}
+
 // categoryResource is the data source implementation.
 type categoryResource struct {
-	client *readme.Client
-	config providerConfig
+	client  *readme.Client
+	config  providerConfig
+	lookups *lookupCache
 }
 
 // NewCategoryResource is a helper function to simplify the provider
@@ -58,6 +63,7 @@ func (r *categoryResource) Configure(
 	cfg := req.ProviderData.(*providerData)
 	r.client = cfg.client
 	r.config = cfg.config
+	r.lookups = cfg.lookups
 }
 
 // ValidateConfig is used for validating attribute values.
@@ -82,6 +88,26 @@ func (r categoryResource) ValidateConfig(
 	}
 }
 
+// ModifyPlan sets the version to the provider's default version if it's not set.
+func (r *categoryResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Skip when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() || r.config.DefaultVersion.ValueString() == "" {
+		return
+	}
+
+	var version types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("version"), &version)...)
+	if resp.Diagnostics.HasError() || !version.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("version"), r.config.DefaultVersion)...)
+}
+
 func (r *categoryResource) Schema(
 	_ context.Context,
 	_ resource.SchemaRequest,
@@ -155,9 +181,10 @@ func (r *categoryResource) Schema(
 				},
 			},
 			"version": schema.StringAttribute{
-				Description: "The 'semver-ish' ReadMe version to create the category under.",
-				Optional:    true,
-				Computed:    true,
+				Description: "The 'semver-ish' ReadMe version to create the category under. Defaults to the " +
+					"provider's `config.default_version` if set.",
+				Optional: true,
+				Computed: true,
 			},
 			"version_id": schema.StringAttribute{
 				Description: "The version ID the category is associated with.",
@@ -176,6 +203,10 @@ func (r *categoryResource) Create(
 	req resource.CreateRequest,
 	resp *resource.CreateResponse,
 ) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "category") {
+		return
+	}
+
 	// Retrieve values from plan.
 	var plan categoryModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -200,7 +231,12 @@ func (r *categoryResource) Create(
 			apiRequestOptions(plan.Version),
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to create category.", clientError(err, apiResponse))
+			resp.Diagnostics.Append(clientDiagnostic(
+				"Unable to create category.",
+				err,
+				apiResponse,
+				nil,
+			))
 		}
 		slug = categoryResponse.Slug
 	} else {
@@ -211,7 +247,12 @@ func (r *categoryResource) Create(
 			apiRequestOptions(plan.Version),
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to create versioned category.", clientError(err, apiResponse))
+			resp.Diagnostics.Append(clientDiagnostic(
+				"Unable to create versioned category.",
+				err,
+				apiResponse,
+				nil,
+			))
 		}
 		slug = categoryResponse.Slug
 	}
@@ -253,7 +294,7 @@ func (r *categoryResource) Read(
 	}
 
 	// Determine the version using the version ID in the state.
-	version := versionClean(ctx, r.client, state.VersionID.ValueString())
+	version := r.lookups.versionClean(ctx, r.client, state.VersionID.ValueString())
 
 	// Get the category metadata.
 	state, apiResponse, err := r.get(
@@ -269,7 +310,7 @@ func (r *categoryResource) Read(
 			return
 		}
 
-		resp.Diagnostics.AddError("Unable to read category.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to read category.", err, apiResponse, categoryErrorPaths))
 
 		return
 	}
@@ -291,6 +332,10 @@ func (r *categoryResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
+	if r.config.readOnly(&resp.Diagnostics, "update", "category") {
+		return
+	}
+
 	// Retrieve values from plan and current state.
 	var plan, state categoryModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -309,8 +354,15 @@ func (r *categoryResource) Update(
 		createParams,
 		apiRequestOptions(plan.Version),
 	)
+	// The category slug changes with its title.
+	r.lookups.invalidate(lookupCategory)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to update category.", clientError(err, apiResponse))
+		resp.Diagnostics.Append(clientDiagnostic(
+			"Unable to update category.",
+			err,
+			apiResponse,
+			categoryErrorPaths,
+		))
 
 		return
 	}
@@ -337,6 +389,10 @@ func (r *categoryResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
+	if r.config.readOnly(&resp.Diagnostics, "delete", "category") {
+		return
+	}
+
 	// Retrieve values from state.
 	var state categoryModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -349,11 +405,14 @@ func (r *categoryResource) Delete(
 		state.Slug.ValueString(),
 		apiRequestOptions(state.Version),
 	)
+	r.lookups.invalidate(lookupCategory)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(clientDiagnostic(
 			fmt.Sprintf("Unable to delete category %s.", state.Slug),
-			clientError(err, apiResponse),
-		)
+			err,
+			apiResponse,
+			categoryErrorPaths,
+		))
 	}
 }
 
@@ -363,8 +422,45 @@ func (r *categoryResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	// Retrieve import ID and save to id attribute.
-	resource.ImportStatePassthroughID(ctx, path.Root("slug"), req, resp)
+	id, err := parseImportID(req.ID, r.config.DefaultVersion.ValueString(), false)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID.", err.Error())
+
+		return
+	}
+
+	categories, apiResponse, err := r.client.Category.GetAll(id.options())
+	if err != nil {
+		resp.Diagnostics.Append(clientDiagnostic("Unable to import category.", err, apiResponse, nil))
+
+		return
+	}
+
+	matches := make([]importMatch, 0, len(categories))
+	for _, category := range categories {
+		matches = append(matches, importMatch{id: category.ID, slug: category.Slug, title: category.Title})
+	}
+
+	match, err := matchImportID("category", id, matches)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import category.", err.Error())
+
+		return
+	}
+
+	// The version ID is used to read the category from the matching version.
+	versionID := ""
+	for _, category := range categories {
+		if category.ID == match.id {
+			versionID = category.Version
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("slug"), match.slug)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version_id"), versionID)...)
+	if id.version != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version"), id.version)...)
+	}
 }
 
 // get is a helper function for retrieving a category and returning the Terraform resource category model for state.
@@ -392,7 +488,7 @@ func (r *categoryResource) get(
 		Slug:         types.StringValue(response.Slug),
 		Title:        types.StringValue(response.Title),
 		Type:         types.StringValue(response.Type),
-		Version:      types.StringValue(versionClean(ctx, r.client, response.Version)),
+		Version:      types.StringValue(r.lookups.versionClean(ctx, r.client, response.Version)),
 		VersionID:    types.StringValue(response.Version),
 	}
 
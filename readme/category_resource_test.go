@@ -552,3 +552,58 @@ func TestCategoryResource_Delete_Error(t *testing.T) {
 		},
 	})
 }
+
+// TestCategoryResource_DefaultVersion tests that the provider's default version is used for a
+// category that doesn't set a version.
+func TestCategoryResource_DefaultVersion(t *testing.T) {
+	// Close all gocks after completion.
+	defer gock.OffAll()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "readme" {
+						api_token = "%s"
+						api_url   = "%s"
+						config = {
+							default_version = "%s"
+						}
+					}
+					resource "readme_category" "test" {
+						title = "%s"
+						type  = "%s"
+					}`,
+					testToken, testURL, mockVersion.VersionClean, mockCategory.Title, mockCategory.Type,
+				),
+				PreConfig: func() {
+					// The category is created in the default version.
+					gock.New(testURL).
+						Post("/categories").
+						MatchHeader("x-readme-version", mockVersion.VersionClean).
+						Times(1).
+						Reply(201).
+						JSON(mockCategory)
+					gock.New(testURL).
+						Get("/categories/" + mockCategory.Slug).
+						Persist().
+						Reply(200).
+						JSON(mockCategory)
+					gock.New(testURL).
+						Get("/version").
+						Persist().
+						Reply(200).
+						JSON(mockVersionList)
+					gock.New(testURL).
+						Delete("/categories/"+mockCategory.Slug).
+						MatchHeader("x-readme-version", mockVersion.VersionClean).
+						Times(1).
+						Reply(204)
+				},
+				Check: resource.TestCheckResourceAttr("readme_category.test", "version", mockVersion.VersionClean),
+			},
+		},
+	})
+}
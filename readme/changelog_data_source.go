@@ -94,9 +94,9 @@ func (d *changelogDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	page, _, err := d.client.Changelog.Get(state.Slug.ValueString())
+	page, apiResponse, err := d.client.Changelog.Get(state.Slug.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to retrieve changelog.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to retrieve changelog.", err, apiResponse, nil))
 
 		return
 	}
@@ -118,7 +118,7 @@ func (d *changelogDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
 
 // Schema for the readme_changelog data source.
@@ -14,13 +14,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/liveoaklabs/readme-api-go-client/readme"
 	"github.com/liveoaklabs/terraform-provider-readme/readme/frontmatter"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/markdown"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &changelogResource{}
-	_ resource.ResourceWithConfigure   = &changelogResource{}
-	_ resource.ResourceWithImportState = &changelogResource{}
+	_ resource.Resource                   = &changelogResource{}
+	_ resource.ResourceWithConfigure      = &changelogResource{}
+	_ resource.ResourceWithImportState    = &changelogResource{}
+	_ resource.ResourceWithModifyPlan     = &changelogResource{}
+	_ resource.ResourceWithValidateConfig = &changelogResource{}
 )
 
 // changelogResource is the data source implementation.
@@ -31,38 +34,55 @@ type changelogResource struct {
 
 // changelogResourceModel is the resource model used by the readme_changelog resource.
 type changelogResourceModel struct {
-	Algolia   types.Object `tfsdk:"algolia"`
-	Body      types.String `tfsdk:"body"`
-	BodyClean types.String `tfsdk:"body_clean"`
-	CreatedAt types.String `tfsdk:"created_at"`
-	HTML      types.String `tfsdk:"html"`
-	Hidden    types.Bool   `tfsdk:"hidden"`
-	ID        types.String `tfsdk:"id"`
-	Metadata  types.Object `tfsdk:"metadata"`
-	Revision  types.Int64  `tfsdk:"revision"`
-	Slug      types.String `tfsdk:"slug"`
-	Title     types.String `tfsdk:"title"`
-	Type      types.String `tfsdk:"type"`
-	UpdatedAt types.String `tfsdk:"updated_at"`
+	Algolia      types.Object   `tfsdk:"algolia"`
+	Body         markdown.Value `tfsdk:"body"`
+	BodyClean    types.String   `tfsdk:"body_clean"`
+	BodyFile     types.String   `tfsdk:"body_file"`
+	BodyHash     types.String   `tfsdk:"body_hash"`
+	BodyRendered types.String   `tfsdk:"body_rendered"`
+	CreatedAt    types.String   `tfsdk:"created_at"`
+	HTML         types.String   `tfsdk:"html"`
+	Hidden       types.Bool     `tfsdk:"hidden"`
+	ID           types.String   `tfsdk:"id"`
+	Metadata     types.Object   `tfsdk:"metadata"`
+	Revision     types.Int64    `tfsdk:"revision"`
+	Slug         types.String   `tfsdk:"slug"`
+	SnippetDirs  types.List     `tfsdk:"snippet_dirs"`
+	TemplateVars types.Map      `tfsdk:"template_vars"`
+	Title        types.String   `tfsdk:"title"`
+	Type         types.String   `tfsdk:"type"`
+	UpdatedAt    types.String   `tfsdk:"updated_at"`
 }
 
 // changelogResourceMapToModel maps a readme.Changelog to a changelogResourceModel
 // for use in the readme_custom_page resource.
 func changelogResourceMapToModel(changelog readme.Changelog, plan changelogResourceModel) changelogResourceModel {
+	bodyClean := types.StringValue(changelog.Body)
+
+	// Only the hash of a body file is kept in the state.
+	if !plan.BodyFile.IsNull() {
+		bodyClean = types.StringNull()
+	}
+
 	return changelogResourceModel{
-		Algolia:   docModelAlgoliaValue(changelog.Algolia),
-		Body:      plan.Body,
-		BodyClean: types.StringValue(changelog.Body),
-		CreatedAt: types.StringValue(changelog.CreatedAt),
-		HTML:      types.StringValue(changelog.HTML),
-		Hidden:    types.BoolValue(changelog.Hidden),
-		ID:        types.StringValue(changelog.ID),
-		Metadata:  docModelMetadataValue(changelog.Metadata),
-		Revision:  types.Int64Value(int64(changelog.Revision)),
-		Slug:      types.StringValue(changelog.Slug),
-		Title:     types.StringValue(changelog.Title),
-		Type:      types.StringValue(changelog.Type),
-		UpdatedAt: types.StringValue(changelog.UpdatedAt),
+		Algolia:      docModelAlgoliaValue(changelog.Algolia),
+		Body:         stateBody(plan.Body, changelog.Body, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs),
+		BodyClean:    bodyClean,
+		BodyFile:     plan.BodyFile,
+		BodyHash:     plan.BodyHash,
+		BodyRendered: plan.BodyRendered,
+		CreatedAt:    types.StringValue(changelog.CreatedAt),
+		HTML:         types.StringValue(changelog.HTML),
+		Hidden:       types.BoolValue(changelog.Hidden),
+		ID:           types.StringValue(changelog.ID),
+		Metadata:     docModelMetadataValue(changelog.Metadata),
+		Revision:     types.Int64Value(int64(changelog.Revision)),
+		Slug:         types.StringValue(changelog.Slug),
+		SnippetDirs:  plan.SnippetDirs,
+		TemplateVars: plan.TemplateVars,
+		Title:        types.StringValue(changelog.Title),
+		Type:         types.StringValue(changelog.Type),
+		UpdatedAt:    types.StringValue(changelog.UpdatedAt),
 	}
 }
 
@@ -87,6 +107,18 @@ func (r *changelogResource) Configure(_ context.Context, req resource.ConfigureR
 	r.config = cfg.config
 }
 
+// ValidateConfig is used for validating attribute values.
+func (r *changelogResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data changelogResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	validateBodyConfig(data.Body.StringValue, data.BodyFile, true, &resp.Diagnostics)
+}
+
 // ModifyPlan is used for modifying the plan before it is applied. In particular,
 // this is used to normalize the body attribute and to update dynamic attributes.
 func (r *changelogResource) ModifyPlan(
@@ -100,15 +132,45 @@ func (r *changelogResource) ModifyPlan(
 	state := &changelogResourceModel{}
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
-	if resp.Diagnostics.HasError() || plan == nil || state == nil {
+	if resp.Diagnostics.HasError() || plan == nil {
 		return
 	}
 
-	body := strings.TrimSpace(plan.Body.ValueString())
+	// Only the hash of a body file is kept in the state.
+	plan.BodyHash = planBodyHash(plan.BodyFile, &resp.Diagnostics)
+	if !plan.BodyFile.IsNull() {
+		plan.BodyClean = types.StringNull()
+	}
+
+	// The body is rendered from its template when templating is enabled.
+	plan.BodyRendered = planBodyRendered(
+		ctx, plan.Body.StringValue, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs, &resp.Diagnostics,
+	)
+
+	if state == nil {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+
+		return
+	}
+
+	if plan.BodyFile.IsNull() {
+		body := plan.Body.ValueString()
+
+		// The rendered body is uploaded when templating is enabled.
+		if !plan.TemplateVars.IsNull() || !plan.SnippetDirs.IsNull() {
+			body = plan.BodyRendered.ValueString()
+		}
+
+		body = strings.TrimSpace(body)
 
-	// Expand newline escape sequences.
-	body = strings.ReplaceAll(body, `\n`, "\n")
-	plan.BodyClean = types.StringValue(body)
+		// Expand newline escape sequences.
+		body = strings.ReplaceAll(body, `\n`, "\n")
+		plan.BodyClean = types.StringValue(body)
+
+		if plan.BodyRendered.IsUnknown() {
+			plan.BodyClean = types.StringUnknown()
+		}
+	}
 
 	if plan.Hidden.IsNull() {
 		plan.Hidden = types.BoolValue(true)
@@ -121,6 +183,8 @@ func (r *changelogResource) ModifyPlan(
 	// This may need to be added to if additional attributes are discovered to
 	// be dynamic.
 	if !state.BodyClean.Equal(plan.BodyClean) ||
+		!state.BodyHash.Equal(plan.BodyHash) ||
+		!state.BodyRendered.Equal(plan.BodyRendered) ||
 		!state.Hidden.Equal(plan.Hidden) ||
 		!state.Title.Equal(plan.Title) ||
 		!state.Type.Equal(plan.Type) {
@@ -144,7 +208,7 @@ func (r *changelogResource) ModifyPlan(
 		})
 	}
 
-	if plan.Title.ValueString() == "" {
+	if plan.Title.ValueString() == "" && !plan.Title.IsUnknown() {
 		resp.Diagnostics.AddError("Title is not set.",
 			"The 'title' attribute is not set. This is a required attribute for the changelog resource "+
 				"and must be set either in the resource configuration or in the front matter of the changelog body.")
@@ -158,6 +222,10 @@ func (r *changelogResource) ModifyPlan(
 
 // Create creates the changelog and sets the initial Terraform state.
 func (r *changelogResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "changelog") {
+		return
+	}
+
 	var plan changelogResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -170,30 +238,51 @@ func (r *changelogResource) Create(ctx context.Context, req resource.CreateReque
 		hidden = boolPoint(true)
 	}
 
+	body, bodyHash, err := applyBody(plan.Body.StringValue, plan.BodyFile, plan.BodyHash)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body_file"), "Unable to create changelog.", err.Error())
+
+		return
+	}
+	plan.BodyHash = bodyHash
+
+	body, bodyRendered, err := applyBodyTemplate(
+		ctx, body, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs, plan.BodyRendered,
+	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Unable to create changelog.", err.Error())
+
+		return
+	}
+	plan.BodyRendered = bodyRendered
+
 	params := readme.ChangelogParams{
 		Title:  plan.Title.ValueString(),
-		Body:   plan.Body.ValueString(),
+		Body:   body,
 		Hidden: hidden,
 		Type:   plan.Type.ValueString(),
 	}
 
-	changelog, _, err := r.client.Changelog.Create(params)
+	changelog, apiResponse, err := r.client.Changelog.Create(params)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to create changelog.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to create changelog.", err, apiResponse, nil))
 
 		return
 	}
 
 	// Get the changelog
-	changelog, _, err = r.client.Changelog.Get(changelog.Slug)
+	changelog, apiResponse, err = r.client.Changelog.Get(changelog.Slug)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to retrieve changelog during create.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to retrieve changelog during create.", err, apiResponse, nil))
 
 		return
 	}
 
 	state := changelogResourceMapToModel(changelog, plan)
 
+	// The planned body is kept after apply. Changes ReadMe makes to it are compared when refreshing.
+	state.Body = plan.Body
+
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -215,7 +304,7 @@ func (r *changelogResource) Read(ctx context.Context, req resource.ReadRequest,
 			return
 		}
 
-		resp.Diagnostics.AddError("Unable to retrieve changelog during read.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to retrieve changelog during read.", err, apiResponse, nil))
 
 		return
 	}
@@ -228,6 +317,10 @@ func (r *changelogResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the changelog and sets the updated Terraform state on success.
 func (r *changelogResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.config.readOnly(&resp.Diagnostics, "update", "changelog") {
+		return
+	}
+
 	var plan, state changelogResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -240,36 +333,61 @@ func (r *changelogResource) Update(ctx context.Context, req resource.UpdateReque
 		hidden = boolPoint(true)
 	}
 
+	body, bodyHash, err := applyBody(plan.Body.StringValue, plan.BodyFile, plan.BodyHash)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body_file"), "Unable to update changelog.", err.Error())
+
+		return
+	}
+	plan.BodyHash = bodyHash
+
+	body, bodyRendered, err := applyBodyTemplate(
+		ctx, body, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs, plan.BodyRendered,
+	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Unable to update changelog.", err.Error())
+
+		return
+	}
+	plan.BodyRendered = bodyRendered
+
 	params := readme.ChangelogParams{
 		Title:  plan.Title.ValueString(),
-		Body:   plan.Body.ValueString(),
+		Body:   body,
 		Hidden: hidden,
 		Type:   plan.Type.ValueString(),
 	}
 
-	_, _, err := r.client.Changelog.Update(state.Slug.ValueString(), params)
+	_, apiResponse, err := r.client.Changelog.Update(state.Slug.ValueString(), params)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to update changelog.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to update changelog.", err, apiResponse, nil))
 
 		return
 	}
 
 	// Get the changelog
-	changelog, _, err := r.client.Changelog.Get(state.Slug.ValueString())
+	changelog, apiResponse, err := r.client.Changelog.Get(state.Slug.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to retrieve changelog during update.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to retrieve changelog during update.", err, apiResponse, nil))
 
 		return
 	}
 
 	state = changelogResourceMapToModel(changelog, plan)
 
+	// The planned body is kept after apply. Changes ReadMe makes to it are compared when refreshing.
+	state.Body = plan.Body
+
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
 
 // Delete deletes the changelog and removes the Terraform state on success.
 func (r *changelogResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.config.readOnly(&resp.Diagnostics, "delete", "changelog") {
+		return
+	}
+
 	var state changelogResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -278,7 +396,12 @@ func (r *changelogResource) Delete(ctx context.Context, req resource.DeleteReque
 
 	_, apiResponse, err := r.client.Changelog.Delete(state.Slug.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to delete changelog", clientError(err, apiResponse))
+		resp.Diagnostics.Append(clientDiagnostic(
+			"Unable to delete changelog",
+			err,
+			apiResponse,
+			nil,
+		))
 	}
 }
 
@@ -319,11 +442,26 @@ func (r *changelogResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"body": schema.StringAttribute{
-				Description: "The body of the changelog. Optionally use front matter to set certain attributes. ",
-				Required:    true,
+				CustomType: markdown.Type{},
+				Description: "The body of the changelog. Optionally use front matter to set certain attributes. " +
+					"One of `body` or `body_file` must be set." + bodyFormattingDescription,
+				Optional: true,
 			},
 			"body_clean": schema.StringAttribute{
-				Description: "The body of the changelog after normalization.",
+				Description:        "The body of the changelog after normalization. This is null when `body_file` is set.",
+				Computed:           true,
+				DeprecationMessage: bodyCleanDeprecation,
+			},
+			"body_file": schema.StringAttribute{
+				Description: bodyFileDescription,
+				Optional:    true,
+			},
+			"body_hash": schema.StringAttribute{
+				Description: bodyHashDescription,
+				Computed:    true,
+			},
+			"body_rendered": schema.StringAttribute{
+				Description: bodyRenderedDescription,
 				Computed:    true,
 			},
 			"created_at": schema.StringAttribute{
@@ -362,6 +500,16 @@ func (r *changelogResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					},
 				},
 			},
+			"snippet_dirs": schema.ListAttribute{
+				Description: snippetDirsDescription,
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"template_vars": schema.MapAttribute{
+				Description: templateVarsDescription,
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"title": schema.StringAttribute{
 				Description: "__REQUIRED.__ The title of the changelog. This can alternatively be set using the `title` front matter key.",
 				Computed:    true,
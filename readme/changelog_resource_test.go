@@ -346,3 +346,53 @@ func TestChangelogResource_ReCreate(t *testing.T) {
 		},
 	})
 }
+
+// Test that the body only shows a difference when its content changes outside of Terraform.
+func TestChangelogResource_BodyNormalized(t *testing.T) {
+	defer gock.OffAll()
+
+	changelog := mockChangelogs[0]
+	changelog.Body = "- one\n\n- two"
+
+	changed := changelog
+	changed.Body = "- one\n\n- three"
+
+	config := testProviderConfig + `
+		resource "readme_changelog" "test" {
+			title = "` + changelog.Title + `"
+			type  = "` + changelog.Type + `"
+			body  = "* one  \n\n\n* two\n"
+		}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// ReadMe's formatting of the body isn't shown as a difference.
+			{
+				PreConfig: func() {
+					gock.OffAll()
+					gock.New(testURL).Post("/changelogs").Times(1).Reply(201).JSON(changelog)
+					gock.New(testURL).Get("/changelogs/" + changelog.Slug).Persist().Reply(200).JSON(changelog)
+				},
+				Config: config,
+				Check: resource.TestCheckResourceAttr(
+					"readme_changelog.test",
+					"body",
+					"* one  \n\n\n* two\n",
+				),
+			},
+			// A change to the content is shown as a difference.
+			{
+				PreConfig: func() {
+					gock.OffAll()
+					gock.New(testURL).Get("/changelogs/" + changelog.Slug).Persist().Reply(200).JSON(changed)
+					gock.New(testURL).Delete("/changelogs/" + changelog.Slug).Times(1).Reply(204)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
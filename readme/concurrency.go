@@ -0,0 +1,179 @@
+package readme
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// limitTransport is an http.RoundTripper that limits the number of concurrent requests to the
+// ReadMe API.
+//
+// A request holds its slot until its response body is closed so that a slow response counts
+// toward the limit.
+type limitTransport struct {
+	slots chan struct{}
+	// next is the transport used to perform the request. http.DefaultTransport is used when nil.
+	next http.RoundTripper
+}
+
+// newLimitTransport returns a limitTransport that allows up to `limit` concurrent requests using
+// the `next` transport.
+func newLimitTransport(limit int, next http.RoundTripper) *limitTransport {
+	return &limitTransport{
+		slots: make(chan struct{}, limit),
+		next:  next,
+	}
+}
+
+// RoundTrip waits for an available slot and performs the request.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err() //nolint:wrapcheck // The context error is returned as-is.
+	}
+
+	release := sync.OnceFunc(func() { <-t.slots })
+
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		release()
+
+		return nil, err
+	}
+
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releaseOnClose is a response body that releases a concurrency slot when closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close closes the response body and releases the slot.
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.release()
+
+	return err //nolint:wrapcheck // The error is passed through from the response body.
+}
+
+// categoryLocks serializes writes to docs within the same category.
+//
+// ReadMe assigns the order and slug of a doc when it's created or updated, so concurrent writes
+// to the same category can produce conflicting orders or duplicate slugs. Writes to different
+// categories are not affected.
+type categoryLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// newCategoryLocks returns an empty set of category locks.
+func newCategoryLocks() *categoryLocks {
+	return &categoryLocks{locks: map[string]*sync.Mutex{}}
+}
+
+// lock acquires the locks for the specified category keys and returns a function that releases
+// them.
+//
+// The locks are always acquired in the same order, so concurrent callers locking overlapping
+// categories can't deadlock. Empty keys are ignored.
+func (l *categoryLocks) lock(ctx context.Context, categoryKeys ...string) func() {
+	if l == nil {
+		return func() {}
+	}
+
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, key := range categoryKeys {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	mutexes := make([]*sync.Mutex, 0, len(keys))
+
+	l.mu.Lock()
+	for _, key := range keys {
+		if _, ok := l.locks[key]; !ok {
+			l.locks[key] = &sync.Mutex{}
+		}
+		mutexes = append(mutexes, l.locks[key])
+	}
+	l.mu.Unlock()
+
+	for i, mutex := range mutexes {
+		tflog.Debug(ctx, "acquiring category lock", map[string]any{"category": keys[i]})
+		mutex.Lock()
+	}
+
+	return func() {
+		for i := len(mutexes) - 1; i >= 0; i-- {
+			mutexes[i].Unlock()
+		}
+	}
+}
+
+// categoryLockKey returns the key used to lock a category within a version. An empty string is
+// returned if the category is unknown.
+func categoryLockKey(version, categorySlug string) string {
+	if categorySlug == "" {
+		return ""
+	}
+
+	return version + "/" + categorySlug
+}
+
+// forEachConcurrent calls fn for each index from 0 to count-1 using at most `workers` goroutines
+// and returns the errors in index order. An index's error is nil if fn succeeded.
+//
+// Indexes that haven't started when the context is canceled return the context's error.
+func forEachConcurrent(ctx context.Context, count, workers int, fn func(i int) error) []error {
+	errs := make([]error, count)
+	if workers < 1 {
+		workers = 1
+	}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, count); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+
+					continue
+				}
+
+				errs[i] = fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,209 @@
+package readme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// slowTransport is an http.RoundTripper that tracks the highest number of concurrent requests.
+type slowTransport struct {
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (s *slowTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	current := s.inFlight.Add(1)
+	for {
+		peak := s.peak.Load()
+		if current <= peak || s.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	s.inFlight.Add(-1)
+
+	return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func TestLimitTransport(t *testing.T) {
+	next := &slowTransport{}
+	transport := newLimitTransport(2, next)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequest(http.MethodGet, testURL+"/docs/test", nil)
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if peak := next.peak.Load(); peak > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", peak)
+	}
+}
+
+func TestLimitTransport_Canceled(t *testing.T) {
+	transport := newLimitTransport(1, &slowTransport{})
+
+	// Hold the only slot by not closing the response body.
+	req, _ := http.NewRequest(http.MethodGet, testURL+"/docs/test", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, testURL+"/docs/test", nil)
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Error("expected an error when the request is canceled while waiting for a slot")
+	}
+}
+
+func TestCategoryLocks(t *testing.T) {
+	locks := newCategoryLocks()
+	ctx := context.Background()
+
+	var inCategory atomic.Int32
+	var wg sync.WaitGroup
+
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Lock overlapping categories in varying order to ensure they can't deadlock.
+			keys := []string{categoryLockKey("1.0", "guides"), categoryLockKey("1.0", "other")}
+			if i%2 == 0 {
+				keys = []string{keys[1], keys[0], ""}
+			}
+
+			unlock := locks.lock(ctx, keys...)
+			defer unlock()
+
+			if inCategory.Add(1) > 1 {
+				t.Error("expected writes to the same category to be serialized")
+			}
+			time.Sleep(time.Millisecond)
+			inCategory.Add(-1)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCategoryLocks_Independent(t *testing.T) {
+	locks := newCategoryLocks()
+	ctx := context.Background()
+
+	unlock := locks.lock(ctx, categoryLockKey("1.0", "guides"))
+	defer unlock()
+
+	done := make(chan struct{})
+	go func() {
+		// A different category, or the same category in a different version, isn't blocked.
+		locks.lock(ctx, categoryLockKey("1.0", "reference"), categoryLockKey("2.0", "guides"))()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("expected locks for different categories to be independent")
+	}
+}
+
+func TestForEachConcurrent(t *testing.T) {
+	var inFlight, peak atomic.Int32
+
+	errs := forEachConcurrent(context.Background(), 10, 3, func(i int) error {
+		current := inFlight.Add(1)
+		for {
+			highest := peak.Load()
+			if current <= highest || peak.CompareAndSwap(highest, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+
+		if i%4 == 0 {
+			return fmt.Errorf("failed %d", i)
+		}
+
+		return nil
+	})
+
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", peak.Load())
+	}
+
+	for i, err := range errs {
+		if (i%4 == 0) != (err != nil) {
+			t.Errorf("unexpected error for index %d: %v", i, err)
+		}
+	}
+}
+
+func TestForEachConcurrent_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := atomic.Int32{}
+	errs := forEachConcurrent(ctx, 5, 2, func(int) error {
+		called.Add(1)
+
+		return nil
+	})
+
+	if called.Load() != 0 {
+		t.Errorf("expected no calls after the context is canceled, got %d", called.Load())
+	}
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected index %d to be canceled, got %v", i, err)
+		}
+	}
+}
+
+func TestProvider_InvalidMaxConcurrentRequests(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "readme" {
+					api_token               = "` + testToken + `"
+					api_url                 = "` + testURL + `"
+					max_concurrent_requests = 0
+				}
+				data "readme_project" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`max_concurrent_requests must be at least 1`),
+			},
+		},
+	})
+}
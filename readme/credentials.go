@@ -0,0 +1,190 @@
+package readme
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// envAPIToken is the environment variable the API token may be set with.
+	envAPIToken = "README_API_TOKEN"
+
+	// apiTokenCommandTimeout is how long the `api_token_command` may run.
+	apiTokenCommandTimeout = 30 * time.Second
+)
+
+// apiTokenConfig is the provider configuration for the sources of the API token.
+type apiTokenConfig struct {
+	Token   string
+	File    string
+	Command []string
+}
+
+// resolveAPIToken returns the API token and a description of where it was found. The description
+// is safe to use in logs and diagnostics since it never includes the token.
+//
+// The token is resolved from the first source that is set, in this order:
+//
+//  1. The `api_token`, `api_token_file`, or `api_token_command` attribute. Only one may be set.
+//  2. The README_API_TOKEN environment variable.
+//  3. The API key stored by the `rdme` CLI when logging in with `rdme login`.
+func resolveAPIToken(ctx context.Context, cfg apiTokenConfig) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	configured := []string{}
+	if cfg.Token != "" {
+		configured = append(configured, "api_token")
+	}
+	if cfg.File != "" {
+		configured = append(configured, "api_token_file")
+	}
+	if len(cfg.Command) > 0 {
+		configured = append(configured, "api_token_command")
+	}
+
+	if len(configured) > 1 {
+		diags.AddAttributeError(
+			path.Root(configured[1]),
+			"Conflicting ReadMe API Token configuration.",
+			fmt.Sprintf("Only one of %s may be set.", strings.Join(configured, ", ")),
+		)
+
+		return "", "", diags
+	}
+
+	switch {
+	case cfg.Token != "":
+		return cfg.Token, "the api_token attribute", diags
+	case cfg.File != "":
+		source := fmt.Sprintf("the api_token_file %s", cfg.File)
+		token, err := readAPITokenFile(cfg.File)
+		if err != nil {
+			diags.AddAttributeError(path.Root("api_token_file"), "Unable to read ReadMe API Token.", err.Error())
+		}
+
+		return token, source, diags
+	case len(cfg.Command) > 0:
+		source := fmt.Sprintf("the api_token_command %s", cfg.Command[0])
+		token, err := runAPITokenCommand(ctx, cfg.Command)
+		if err != nil {
+			diags.AddAttributeError(path.Root("api_token_command"), "Unable to read ReadMe API Token.", err.Error())
+		}
+
+		return token, source, diags
+	}
+
+	if token := os.Getenv(envAPIToken); token != "" {
+		return token, "the " + envAPIToken + " environment variable", diags
+	}
+
+	configPath, err := rdmeConfigPath()
+	if err != nil {
+		tflog.Debug(ctx, "Unable to determine the rdme CLI config path", map[string]any{"error": err.Error()})
+
+		return "", "", diags
+	}
+
+	token, err := readRdmeConfigToken(configPath)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to read the API token from the rdme CLI config", map[string]any{
+			"path":  configPath,
+			"error": err.Error(),
+		})
+
+		return "", "", diags
+	}
+
+	return token, fmt.Sprintf("the rdme CLI config %s", configPath), diags
+}
+
+// readAPITokenFile returns the API token from a file, ignoring surrounding whitespace.
+func readAPITokenFile(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("unable to read api_token_file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("the api_token_file %s is empty", file)
+	}
+
+	return token, nil
+}
+
+// runAPITokenCommand runs a command and returns the API token it writes to stdout, ignoring
+// surrounding whitespace.
+func runAPITokenCommand(ctx context.Context, command []string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...) //nolint:gosec // The command is configured by the user.
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		detail := strings.TrimSpace(stderr.String())
+		if detail != "" {
+			detail = "\n" + detail
+		}
+
+		return "", fmt.Errorf("the api_token_command %s failed: %w%s", command[0], err, detail)
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("the api_token_command %s did not output a token", command[0])
+	}
+
+	return token, nil
+}
+
+// rdmeConfigPath returns the path of the config file the `rdme` CLI stores the API key in.
+//
+// The rdme CLI uses the configstore package, which stores its files in the XDG config directory.
+func rdmeConfigPath() (string, error) {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("unable to determine home directory: %w", err)
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configDir, "configstore", "rdme-production.json"), nil
+}
+
+// readRdmeConfigToken returns the API key from an rdme CLI config file.
+func readRdmeConfigToken(configPath string) (string, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to read rdme config: %w", err)
+	}
+
+	var config struct {
+		APIKey string `json:"apiKey"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", fmt.Errorf("unable to parse rdme config: %w", err)
+	}
+
+	if config.APIKey == "" {
+		return "", errors.New("the rdme config does not include an API key")
+	}
+
+	return config.APIKey, nil
+}
@@ -0,0 +1,127 @@
+package readme
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveAPIToken(t *testing.T) {
+	dir := t.TempDir()
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("  token-from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rdmeConfigDir := filepath.Join(dir, "config")
+	if err := os.MkdirAll(filepath.Join(rdmeConfigDir, "configstore"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	rdmeConfig := filepath.Join(rdmeConfigDir, "configstore", "rdme-production.json")
+	if err := os.WriteFile(rdmeConfig, []byte(`{"apiKey":"token-from-rdme","project":"test"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		config       apiTokenConfig
+		env          string
+		configHome   string
+		expectToken  string
+		expectSource string
+		expectError  string
+	}{
+		"attribute takes precedence over the environment": {
+			config:       apiTokenConfig{Token: "token-from-attribute"},
+			env:          "token-from-env",
+			expectToken:  "token-from-attribute",
+			expectSource: "the api_token attribute",
+		},
+		"file": {
+			config:       apiTokenConfig{File: tokenFile},
+			env:          "token-from-env",
+			expectToken:  "token-from-file",
+			expectSource: "the api_token_file " + tokenFile,
+		},
+		"empty file": {
+			config:      apiTokenConfig{File: emptyFile},
+			expectError: "is empty",
+		},
+		"missing file": {
+			config:      apiTokenConfig{File: filepath.Join(dir, "missing")},
+			expectError: "unable to read api_token_file",
+		},
+		"command": {
+			config:       apiTokenConfig{Command: []string{"echo", "token-from-command"}},
+			expectToken:  "token-from-command",
+			expectSource: "the api_token_command echo",
+		},
+		"failed command": {
+			config:      apiTokenConfig{Command: []string{"sh", "-c", "echo oops >&2; exit 1"}},
+			expectError: "oops",
+		},
+		"conflicting attributes": {
+			config:      apiTokenConfig{Token: "token", File: tokenFile},
+			expectError: "Only one of api_token, api_token_file may be set",
+		},
+		"environment takes precedence over the rdme CLI": {
+			env:          "token-from-env",
+			configHome:   rdmeConfigDir,
+			expectToken:  "token-from-env",
+			expectSource: "the README_API_TOKEN environment variable",
+		},
+		"rdme CLI": {
+			configHome:   rdmeConfigDir,
+			expectToken:  "token-from-rdme",
+			expectSource: "the rdme CLI config " + rdmeConfig,
+		},
+		"no token": {
+			configHome: filepath.Join(dir, "missing"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(envAPIToken, tc.env)
+			configHome := tc.configHome
+			if configHome == "" {
+				configHome = filepath.Join(dir, "missing")
+			}
+			t.Setenv("XDG_CONFIG_HOME", configHome)
+
+			token, source, diags := resolveAPIToken(context.Background(), tc.config)
+
+			if tc.expectError != "" {
+				if !diags.HasError() {
+					t.Fatal("expected an error")
+				}
+				detail := diags.Errors()[0].Detail()
+				if !strings.Contains(detail, tc.expectError) {
+					t.Errorf("expected error to contain '%s', got '%s'", tc.expectError, detail)
+				}
+
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if token != tc.expectToken {
+				t.Errorf("expected token '%s', got '%s'", tc.expectToken, token)
+			}
+			if source != tc.expectSource {
+				t.Errorf("expected source '%s', got '%s'", tc.expectSource, source)
+			}
+			if token != "" && strings.Contains(source, token) {
+				t.Errorf("expected the source not to include the token: %s", source)
+			}
+		})
+	}
+}
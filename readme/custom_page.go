@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/markdown"
 )
 
 // customPageDataSourceModel is the data source model used by the
@@ -66,29 +67,41 @@ func customPageResourceMapToModel(
 	plan customPageResourceModel,
 ) customPageResourceModel {
 	if plan.Body.IsUnknown() {
-		plan.Body = types.StringValue("")
+		plan.Body = markdown.NewValue("")
 	}
 
 	if plan.HTML.IsUnknown() {
 		plan.HTML = types.StringValue("")
 	}
 
+	bodyClean := types.StringValue(page.Body)
+
+	// Only the hash of a body file is kept in the state.
+	if !plan.BodyFile.IsNull() {
+		bodyClean = types.StringNull()
+	}
+
 	return customPageResourceModel{
-		Algolia:    docModelAlgoliaValue(page.Algolia),
-		Body:       plan.Body,
-		BodyClean:  types.StringValue(page.Body),
-		CreatedAt:  types.StringValue(page.CreatedAt),
-		FullScreen: types.BoolValue(page.Fullscreen),
-		HTML:       plan.HTML,
-		HTMLClean:  types.StringValue(page.HTML),
-		HTMLMode:   types.BoolValue(page.HTMLMode),
-		Hidden:     types.BoolValue(page.Hidden),
-		ID:         types.StringValue(page.ID),
-		Metadata:   docModelMetadataValue(page.Metadata),
-		Revision:   types.Int64Value(int64(page.Revision)),
-		Slug:       types.StringValue(page.Slug),
-		Title:      types.StringValue(page.Title),
-		UpdatedAt:  types.StringValue(page.UpdatedAt),
+		Algolia:      docModelAlgoliaValue(page.Algolia),
+		Body:         stateBody(plan.Body, page.Body, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs),
+		BodyClean:    bodyClean,
+		BodyFile:     plan.BodyFile,
+		BodyHash:     plan.BodyHash,
+		BodyRendered: plan.BodyRendered,
+		CreatedAt:    types.StringValue(page.CreatedAt),
+		FullScreen:   types.BoolValue(page.Fullscreen),
+		HTML:         plan.HTML,
+		HTMLClean:    types.StringValue(page.HTML),
+		HTMLMode:     types.BoolValue(page.HTMLMode),
+		Hidden:       types.BoolValue(page.Hidden),
+		ID:           types.StringValue(page.ID),
+		Metadata:     docModelMetadataValue(page.Metadata),
+		Revision:     types.Int64Value(int64(page.Revision)),
+		Slug:         types.StringValue(page.Slug),
+		SnippetDirs:  plan.SnippetDirs,
+		TemplateVars: plan.TemplateVars,
+		Title:        types.StringValue(page.Title),
+		UpdatedAt:    types.StringValue(page.UpdatedAt),
 	}
 }
 
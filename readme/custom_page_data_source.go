@@ -43,9 +43,9 @@ func (d *customPageDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	page, _, err := d.client.CustomPage.Get(state.Slug.ValueString())
+	page, apiResponse, err := d.client.CustomPage.Get(state.Slug.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to retrieve custom pages.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to retrieve custom pages.", err, apiResponse, nil))
 
 		return
 	}
@@ -67,7 +67,7 @@ func (d *customPageDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
 
 // Schema for the readme_custom_page data source.
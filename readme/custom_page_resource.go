@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -11,15 +12,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/liveoaklabs/readme-api-go-client/readme"
 	"github.com/liveoaklabs/terraform-provider-readme/readme/frontmatter"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/markdown"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &customPageResource{}
-	_ resource.ResourceWithConfigure   = &customPageResource{}
-	_ resource.ResourceWithImportState = &customPageResource{}
+	_ resource.Resource                   = &customPageResource{}
+	_ resource.ResourceWithConfigure      = &customPageResource{}
+	_ resource.ResourceWithImportState    = &customPageResource{}
+	_ resource.ResourceWithModifyPlan     = &customPageResource{}
+	_ resource.ResourceWithValidateConfig = &customPageResource{}
 )
 
 // customPageResource is the data source implementation.
@@ -30,21 +35,26 @@ type customPageResource struct {
 
 // customPageResourceModel is the resource model used by the readme_custom_page resource.
 type customPageResourceModel struct {
-	Algolia    types.Object `tfsdk:"algolia"`
-	Body       types.String `tfsdk:"body"`
-	BodyClean  types.String `tfsdk:"body_clean"`
-	CreatedAt  types.String `tfsdk:"created_at"`
-	FullScreen types.Bool   `tfsdk:"fullscreen"`
-	HTML       types.String `tfsdk:"html"`
-	HTMLClean  types.String `tfsdk:"html_clean"`
-	HTMLMode   types.Bool   `tfsdk:"html_mode"`
-	Hidden     types.Bool   `tfsdk:"hidden"`
-	ID         types.String `tfsdk:"id"`
-	Metadata   types.Object `tfsdk:"metadata"`
-	Revision   types.Int64  `tfsdk:"revision"`
-	Slug       types.String `tfsdk:"slug"`
-	Title      types.String `tfsdk:"title"`
-	UpdatedAt  types.String `tfsdk:"updated_at"`
+	Algolia      types.Object   `tfsdk:"algolia"`
+	Body         markdown.Value `tfsdk:"body"`
+	BodyClean    types.String   `tfsdk:"body_clean"`
+	BodyFile     types.String   `tfsdk:"body_file"`
+	BodyHash     types.String   `tfsdk:"body_hash"`
+	BodyRendered types.String   `tfsdk:"body_rendered"`
+	CreatedAt    types.String   `tfsdk:"created_at"`
+	FullScreen   types.Bool     `tfsdk:"fullscreen"`
+	HTML         types.String   `tfsdk:"html"`
+	HTMLClean    types.String   `tfsdk:"html_clean"`
+	HTMLMode     types.Bool     `tfsdk:"html_mode"`
+	Hidden       types.Bool     `tfsdk:"hidden"`
+	ID           types.String   `tfsdk:"id"`
+	Metadata     types.Object   `tfsdk:"metadata"`
+	Revision     types.Int64    `tfsdk:"revision"`
+	Slug         types.String   `tfsdk:"slug"`
+	SnippetDirs  types.List     `tfsdk:"snippet_dirs"`
+	TemplateVars types.Map      `tfsdk:"template_vars"`
+	Title        types.String   `tfsdk:"title"`
+	UpdatedAt    types.String   `tfsdk:"updated_at"`
 }
 
 // NewCustomPageResource is a helper function to simplify the provider implementation.
@@ -85,9 +95,26 @@ func (r customPageResource) ValidateConfig(
 	var data customPageResourceModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
+	validateBodyConfig(data.Body.StringValue, data.BodyFile, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || data.BodyFile.IsUnknown() {
+		return
+	}
+
 	if data.Title.IsNull() {
+		body, known, err := frontmatter.RenderBody(ctx, data.Body.StringValue, data.BodyFile, data.TemplateVars, data.SnippetDirs)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("body"), "Unable to read body.", err.Error())
+
+			return
+		}
+
+		// The front matter can't be checked until the body can be rendered.
+		if !known {
+			return
+		}
+
 		// check front matter for 'title'.
-		titleMatter, diag := frontmatter.GetValue(ctx, data.Body.ValueString(), "Title")
+		titleMatter, diag := frontmatter.GetValue(ctx, body, "Title")
 		if diag != "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("title"),
@@ -111,8 +138,65 @@ func (r customPageResource) ValidateConfig(
 	}
 }
 
+// ModifyPlan is used for modifying the plan before it is applied. In particular,
+// this is used to hash the body file and to update dynamic attributes.
+func (r *customPageResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	plan := &customPageResourceModel{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	state := &customPageResourceModel{}
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || plan == nil {
+		return
+	}
+
+	// Only the hash of a body file is kept in the state.
+	plan.BodyHash = planBodyHash(plan.BodyFile, &resp.Diagnostics)
+	if !plan.BodyFile.IsNull() {
+		plan.BodyClean = types.StringNull()
+	}
+
+	// The body is rendered from its template when templating is enabled.
+	plan.BodyRendered = planBodyRendered(
+		ctx, plan.Body.StringValue, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs, &resp.Diagnostics,
+	)
+
+	// The other attributes aren't refreshed by Terraform when only the body file contents or the
+	// rendered body changed.
+	if state != nil && (!state.BodyHash.Equal(plan.BodyHash) || !state.BodyRendered.Equal(plan.BodyRendered)) {
+		tflog.Info(ctx, "Custom page body file has changed. Refreshing dynamic attributes.")
+
+		plan.Algolia = types.ObjectUnknown(map[string]attr.Type{
+			"record_count":    types.Int64Type,
+			"publish_pending": types.BoolType,
+			"updated_at":      types.StringType,
+		})
+		plan.HTMLClean = types.StringUnknown()
+		plan.Revision = types.Int64Unknown()
+		plan.UpdatedAt = types.StringUnknown()
+		plan.Metadata = types.ObjectUnknown(map[string]attr.Type{
+			"description": types.StringType,
+			"image": types.ListType{
+				ElemType: types.StringType,
+			},
+			"title": types.StringType,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
 // Create creates the custom page and sets the initial Terraform state.
 func (r *customPageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "custom page") {
+		return
+	}
+
 	var plan customPageResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -120,23 +204,44 @@ func (r *customPageResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	body, bodyHash, err := applyBody(plan.Body.StringValue, plan.BodyFile, plan.BodyHash)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body_file"), "Unable to create custom page.", err.Error())
+
+		return
+	}
+	plan.BodyHash = bodyHash
+
+	body, bodyRendered, err := applyBodyTemplate(
+		ctx, body, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs, plan.BodyRendered,
+	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Unable to create custom page.", err.Error())
+
+		return
+	}
+	plan.BodyRendered = bodyRendered
+
 	params := readme.CustomPageParams{
 		Title:    plan.Title.ValueString(),
-		Body:     plan.Body.ValueString(),
+		Body:     body,
 		HTML:     plan.HTML.ValueString(),
 		HTMLMode: plan.HTMLMode.ValueBoolPointer(),
 		Hidden:   plan.Hidden.ValueBoolPointer(),
 	}
 
-	page, _, err := r.client.CustomPage.Create(params)
+	page, apiResponse, err := r.client.CustomPage.Create(params)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to create custom page.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to create custom page.", err, apiResponse, nil))
 
 		return
 	}
 
 	state := customPageResourceMapToModel(page, plan)
 
+	// The planned body is kept after apply. Changes ReadMe makes to it are compared when refreshing.
+	state.Body = plan.Body
+
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -150,9 +255,9 @@ func (r *customPageResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	page, _, err := r.client.CustomPage.Get(state.Slug.ValueString())
+	page, apiResponse, err := r.client.CustomPage.Get(state.Slug.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to retrieve custom page.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to retrieve custom page.", err, apiResponse, nil))
 
 		return
 	}
@@ -165,6 +270,10 @@ func (r *customPageResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the custom page and sets the updated Terraform state on success.
 func (r *customPageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.config.readOnly(&resp.Diagnostics, "update", "custom page") {
+		return
+	}
+
 	var plan, state customPageResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -172,29 +281,54 @@ func (r *customPageResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	body, bodyHash, err := applyBody(plan.Body.StringValue, plan.BodyFile, plan.BodyHash)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body_file"), "Unable to update custom page.", err.Error())
+
+		return
+	}
+	plan.BodyHash = bodyHash
+
+	body, bodyRendered, err := applyBodyTemplate(
+		ctx, body, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs, plan.BodyRendered,
+	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Unable to update custom page.", err.Error())
+
+		return
+	}
+	plan.BodyRendered = bodyRendered
+
 	params := readme.CustomPageParams{
 		Title:    plan.Title.ValueString(),
-		Body:     plan.Body.ValueString(),
+		Body:     body,
 		HTML:     plan.HTML.ValueString(),
 		HTMLMode: plan.HTMLMode.ValueBoolPointer(),
 		Hidden:   plan.Hidden.ValueBoolPointer(),
 	}
 
-	page, _, err := r.client.CustomPage.Update(state.Slug.ValueString(), params)
+	page, apiResponse, err := r.client.CustomPage.Update(state.Slug.ValueString(), params)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to update custom page.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to update custom page.", err, apiResponse, nil))
 
 		return
 	}
 
 	state = customPageResourceMapToModel(page, plan)
 
+	// The planned body is kept after apply. Changes ReadMe makes to it are compared when refreshing.
+	state.Body = plan.Body
+
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
 
 // Delete deletes the custom page and removes the Terraform state on success.
 func (r *customPageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.config.readOnly(&resp.Diagnostics, "delete", "custom page") {
+		return
+	}
+
 	var state customPageResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -203,7 +337,12 @@ func (r *customPageResource) Delete(ctx context.Context, req resource.DeleteRequ
 
 	_, apiResponse, err := r.client.CustomPage.Delete(state.Slug.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to delete custom page", clientError(err, apiResponse))
+		resp.Diagnostics.Append(clientDiagnostic(
+			"Unable to delete custom page",
+			err,
+			apiResponse,
+			nil,
+		))
 	}
 }
 
@@ -240,16 +379,41 @@ func (r *customPageResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 			},
 			"body": schema.StringAttribute{
+				CustomType: markdown.Type{},
 				Description: "The body of the custom page. Optionally use front matter to set certain attributes. " +
-					"Alternatively, use the `html_mode` and `html` attributes to set the body in HTML format.",
+					"Alternatively, use the `html_mode` and `html` attributes to set the body in HTML format." +
+					bodyFormattingDescription,
 				Computed: true,
 				Optional: true,
 				Default:  stringdefault.StaticString(""),
 			},
 			"body_clean": schema.StringAttribute{
-				Description: "The body of the custom page after normalization.",
+				Description:        "The body of the custom page after normalization. This is null when `body_file` is set.",
+				Computed:           true,
+				DeprecationMessage: bodyCleanDeprecation,
+			},
+			"body_file": schema.StringAttribute{
+				Description: bodyFileDescription,
+				Optional:    true,
+			},
+			"body_hash": schema.StringAttribute{
+				Description: bodyHashDescription,
+				Computed:    true,
+			},
+			"body_rendered": schema.StringAttribute{
+				Description: bodyRenderedDescription,
 				Computed:    true,
 			},
+			"snippet_dirs": schema.ListAttribute{
+				Description: snippetDirsDescription,
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"template_vars": schema.MapAttribute{
+				Description: templateVarsDescription,
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"html": schema.StringAttribute{
 				Description: "The body source formatted in HTML. Only displayed if `htmlmode` is set to `true`. " +
 					"Leading and trailing whitespace and certain HTML tags are removed when uploaded to ReadMe. " +
@@ -51,9 +51,9 @@ func (d *customPagesDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	results := []customPageDataSourceModel{}
 
-	pages, _, err := d.client.CustomPage.GetAll()
+	pages, apiResponse, err := d.client.CustomPage.GetAll()
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to retrieve custom pages.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to retrieve custom pages.", err, apiResponse, nil))
 
 		return
 	}
@@ -83,7 +83,7 @@ func (d *customPagesDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
 
 // Schema for the readme_custom_pages data source.
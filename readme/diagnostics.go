@@ -0,0 +1,179 @@
+package readme
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+// apiErrorCode describes a known ReadMe API error code.
+type apiErrorCode struct {
+	// summary is a short description of the error that leads the diagnostic detail.
+	summary string
+	// attribute is the attribute the error is scoped to by default. An empty attribute scopes the
+	// error to the whole resource or data source.
+	attribute string
+}
+
+// apiErrorCodes are the ReadMe API error codes that are mapped to actionable diagnostics.
+//
+// See https://docs.readme.com/main/reference/intro#errors for the list of error codes.
+var apiErrorCodes = map[string]apiErrorCode{
+	"APIKEY_EMPTY": {
+		summary: "No ReadMe API token was sent with the request. Set the provider's api_token.",
+	},
+	"APIKEY_NOTFOUND": {
+		summary: "The ReadMe API token is not valid. Verify the provider's api_token.",
+	},
+	"APIKEY_MISMATCH": {
+		summary: "The ReadMe API token does not belong to the project the request was made for.",
+	},
+	"API_ACCESS_REVOKED": {
+		summary: "API access for the ReadMe project has been revoked.",
+	},
+	"API_ACCESS_UNAVAILABLE": {
+		summary: "API access is not available for the ReadMe project's plan.",
+	},
+	"CATEGORY_NOTFOUND": {
+		summary:   "The category was not found.",
+		attribute: "category_slug",
+	},
+	"CHANGELOG_NOTFOUND": {
+		summary:   "The changelog was not found.",
+		attribute: "slug",
+	},
+	"CUSTOMPAGE_NOTFOUND": {
+		summary:   "The custom page was not found.",
+		attribute: "slug",
+	},
+	"DOC_NOTFOUND": {
+		summary:   "The doc was not found.",
+		attribute: "slug",
+	},
+	"SPEC_NOTFOUND": {
+		summary:   "The API specification was not found.",
+		attribute: "id",
+	},
+	"VERSION_NOTFOUND": {
+		summary:   "The version was not found.",
+		attribute: "version",
+	},
+}
+
+// apiErrorStatuses are short descriptions for HTTP statuses that are actionable regardless of the
+// ReadMe error code.
+var apiErrorStatuses = map[int]string{
+	http.StatusUnauthorized:    "The ReadMe API token was rejected. Verify the provider's api_token.",
+	http.StatusForbidden:       "The ReadMe API token is not permitted to make the request.",
+	http.StatusTooManyRequests: "The ReadMe API rate limit was exceeded. Lower max_concurrent_requests or try again later.",
+}
+
+// requestIDHeaders are the response headers that identify a request for ReadMe support.
+var requestIDHeaders = []string{"X-Request-Id", "Cf-Ray"}
+
+// apiErrorPaths maps ReadMe API error codes to the attribute an error is scoped to, overriding the
+// default attribute for the code. An empty path scopes the error to the whole resource.
+type apiErrorPaths map[string]path.Path
+
+// clientDiagnostic returns an error diagnostic for a failed API request.
+//
+// Known ReadMe error codes are scoped to the attribute that caused them so Terraform can point to
+// the offending configuration. The `paths` override the default attribute for a code when a
+// resource names it differently, such as the `slug` of a category resource.
+func clientDiagnostic(
+	summary string,
+	err error,
+	apiResponse *readme.APIResponse,
+	paths apiErrorPaths,
+) diag.Diagnostic {
+	detail := clientError(err, apiResponse)
+
+	attribute, ok := clientErrorPath(apiResponse, paths)
+	if !ok {
+		return diag.NewErrorDiagnostic(summary, detail)
+	}
+
+	return diag.NewAttributeErrorDiagnostic(attribute, summary, detail)
+}
+
+// clientErrorPath returns the attribute path an API error is scoped to, if any.
+func clientErrorPath(apiResponse *readme.APIResponse, paths apiErrorPaths) (path.Path, bool) {
+	if apiResponse == nil || apiResponse.APIErrorResponse.Error == "" {
+		return path.Empty(), false
+	}
+
+	code := apiResponse.APIErrorResponse.Error
+
+	if attribute, ok := paths[code]; ok {
+		return attribute, len(attribute.Steps()) > 0
+	}
+
+	if known, ok := apiErrorCodes[code]; ok && known.attribute != "" {
+		return path.Root(known.attribute), true
+	}
+
+	return path.Empty(), false
+}
+
+// clientErrorDetail formats the detail of a diagnostic for a failed API request.
+//
+// The detail leads with a short description of known errors, followed by the ReadMe error message
+// and suggestion, links to more information, the HTTP status, and the request ID.
+func clientErrorDetail(err error, apiResponse *readme.APIResponse) string {
+	if apiResponse == nil {
+		return err.Error()
+	}
+
+	apiErr := apiResponse.APIErrorResponse
+	lines := []string{}
+
+	if known, ok := apiErrorCodes[apiErr.Error]; ok {
+		lines = append(lines, known.summary)
+	} else if apiResponse.HTTPResponse != nil {
+		if status, ok := apiErrorStatuses[apiResponse.HTTPResponse.StatusCode]; ok {
+			lines = append(lines, status)
+		}
+	}
+
+	if apiErr.Message != "" {
+		lines = append(lines, apiErr.Message)
+	} else {
+		lines = append(lines, err.Error())
+	}
+
+	fields := [][2]string{
+		{"Suggestion", apiErr.Suggestion},
+		{"Error Code", apiErr.Error},
+		{"Docs", apiErr.Docs},
+		{"Help", apiErr.Help},
+	}
+
+	if resp := apiResponse.HTTPResponse; resp != nil {
+		fields = append(fields, [2]string{"HTTP Status", fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))})
+
+		for _, header := range requestIDHeaders {
+			if id := resp.Header.Get(header); id != "" {
+				fields = append(fields, [2]string{"Request ID", id})
+
+				break
+			}
+		}
+	}
+
+	details := []string{}
+	for _, field := range fields {
+		if field[1] != "" {
+			details = append(details, fmt.Sprintf("%s: %s", field[0], field[1]))
+		}
+	}
+
+	if len(details) > 0 {
+		lines = append(lines, "", strings.Join(details, "\n"))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,120 @@
+package readme
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+func TestClientDiagnostic(t *testing.T) {
+	err := errors.New("API responded with a non-OK status: 404")
+
+	notFound := func(code string) *readme.APIResponse {
+		return &readme.APIResponse{
+			APIErrorResponse: readme.APIErrorResponse{
+				Error:      code,
+				Message:    "The resource couldn't be found.",
+				Suggestion: "Check the slug.",
+				Docs:       "https://docs.readme.com/logs/123",
+				Help:       "Contact support@readme.io.",
+			},
+			HTTPResponse: fakeResponse(404, map[string]string{"X-Request-Id": "req-123"}),
+		}
+	}
+
+	tests := map[string]struct {
+		apiResponse  *readme.APIResponse
+		paths        apiErrorPaths
+		expectPath   path.Path
+		expectDetail []string
+	}{
+		"known code is scoped to its default attribute": {
+			apiResponse: notFound("DOC_NOTFOUND"),
+			expectPath:  path.Root("slug"),
+			expectDetail: []string{
+				"The doc was not found.",
+				"The resource couldn't be found.",
+				"Suggestion: Check the slug.",
+				"Error Code: DOC_NOTFOUND",
+				"Docs: https://docs.readme.com/logs/123",
+				"Help: Contact support@readme.io.",
+				"HTTP Status: 404 Not Found",
+				"Request ID: req-123",
+			},
+		},
+		"attribute is overridden": {
+			apiResponse:  notFound("CATEGORY_NOTFOUND"),
+			paths:        categoryErrorPaths,
+			expectPath:   path.Root("slug"),
+			expectDetail: []string{"The category was not found."},
+		},
+		"attribute override removes the scope": {
+			apiResponse:  notFound("VERSION_NOTFOUND"),
+			paths:        apiErrorPaths{"VERSION_NOTFOUND": path.Empty()},
+			expectDetail: []string{"The version was not found."},
+		},
+		"unknown code is not scoped": {
+			apiResponse:  notFound("SOMETHING_ELSE"),
+			expectDetail: []string{"The resource couldn't be found.", "Error Code: SOMETHING_ELSE"},
+		},
+		"rate limited": {
+			apiResponse: &readme.APIResponse{
+				HTTPResponse: fakeResponse(429, nil),
+			},
+			expectDetail: []string{
+				"rate limit was exceeded",
+				err.Error(),
+				"HTTP Status: 429 Too Many Requests",
+			},
+		},
+		"invalid token": {
+			apiResponse: &readme.APIResponse{
+				APIErrorResponse: readme.APIErrorResponse{
+					Error:   "APIKEY_NOTFOUND",
+					Message: "We couldn't find your API key.",
+				},
+				HTTPResponse: fakeResponse(401, nil),
+			},
+			expectDetail: []string{
+				"The ReadMe API token is not valid.",
+				"We couldn't find your API key.",
+				"HTTP Status: 401 Unauthorized",
+			},
+		},
+		"no response": {
+			expectDetail: []string{err.Error()},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			diagnostic := clientDiagnostic("Unable to read.", err, tc.apiResponse, tc.paths)
+
+			if diagnostic.Severity() != diag.SeverityError {
+				t.Errorf("expected an error diagnostic, got %s", diagnostic.Severity())
+			}
+			if diagnostic.Summary() != "Unable to read." {
+				t.Errorf("unexpected summary: %s", diagnostic.Summary())
+			}
+
+			withPath, scoped := diagnostic.(diag.DiagnosticWithPath)
+			if len(tc.expectPath.Steps()) == 0 {
+				if scoped {
+					t.Errorf("expected no attribute, got %s", withPath.Path())
+				}
+			} else if !scoped || !withPath.Path().Equal(tc.expectPath) {
+				t.Errorf("expected attribute %s, got %v", tc.expectPath, diagnostic)
+			}
+
+			for _, expected := range tc.expectDetail {
+				if !strings.Contains(diagnostic.Detail(), expected) {
+					t.Errorf("expected detail to contain '%s', got:\n%s", expected, diagnostic.Detail())
+				}
+			}
+		})
+	}
+}
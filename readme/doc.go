@@ -2,6 +2,7 @@ package readme
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -11,47 +12,55 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/markdown"
 )
 
 // docModel defines the fields and their types that map to the schemas.
 type docModel struct {
-	Algolia         types.Object `tfsdk:"algolia"`
-	API             types.Object `tfsdk:"api"`
-	Body            types.String `tfsdk:"body"`
-	BodyClean       types.String `tfsdk:"body_clean"`
-	BodyHTML        types.String `tfsdk:"body_html"`
-	Category        types.String `tfsdk:"category"`
-	CategorySlug    types.String `tfsdk:"category_slug"`
-	CreatedAt       types.String `tfsdk:"created_at"`
-	Deprecated      types.Bool   `tfsdk:"deprecated"`
-	Excerpt         types.String `tfsdk:"excerpt"`
-	Hidden          types.Bool   `tfsdk:"hidden"`
-	ID              types.String `tfsdk:"id"`
-	Icon            types.String `tfsdk:"icon"`
-	IsAPI           types.Bool   `tfsdk:"is_api"`
-	IsReference     types.Bool   `tfsdk:"is_reference"`
-	LinkExternal    types.Bool   `tfsdk:"link_external"`
-	LinkURL         types.String `tfsdk:"link_url"`
-	Error           types.Object `tfsdk:"error"`
-	Metadata        types.Object `tfsdk:"metadata"`
-	Next            types.Object `tfsdk:"next"`
-	ParentDoc       types.String `tfsdk:"parent_doc"`
-	ParentDocSlug   types.String `tfsdk:"parent_doc_slug"`
-	Order           types.Int64  `tfsdk:"order"`
-	PreviousSlug    types.String `tfsdk:"previous_slug"`
-	Project         types.String `tfsdk:"project"`
-	Revision        types.Int64  `tfsdk:"revision"`
-	Slug            types.String `tfsdk:"slug"`
-	SlugUpdatedAt   types.String `tfsdk:"slug_updated_at"`
-	SyncUnique      types.String `tfsdk:"sync_unique"`
-	Title           types.String `tfsdk:"title"`
-	Type            types.String `tfsdk:"type"`
-	UpdatedAt       types.String `tfsdk:"updated_at"`
-	User            types.String `tfsdk:"user"`
-	UseSlug         types.String `tfsdk:"use_slug"`
-	VerifyParentDoc types.Bool   `tfsdk:"verify_parent_doc"`
-	Version         types.String `tfsdk:"version"`
-	VersionID       types.String `tfsdk:"version_id"`
+	Algolia         types.Object   `tfsdk:"algolia"`
+	API             types.Object   `tfsdk:"api"`
+	Body            markdown.Value `tfsdk:"body"`
+	BodyClean       types.String   `tfsdk:"body_clean"`
+	BodyFile        types.String   `tfsdk:"body_file"`
+	BodyHash        types.String   `tfsdk:"body_hash"`
+	BodyHTML        types.String   `tfsdk:"body_html"`
+	BodyRendered    types.String   `tfsdk:"body_rendered"`
+	Category        types.String   `tfsdk:"category"`
+	CategorySlug    types.String   `tfsdk:"category_slug"`
+	CreatedAt       types.String   `tfsdk:"created_at"`
+	Deprecated      types.Bool     `tfsdk:"deprecated"`
+	Excerpt         types.String   `tfsdk:"excerpt"`
+	Hidden          types.Bool     `tfsdk:"hidden"`
+	ID              types.String   `tfsdk:"id"`
+	Icon            types.String   `tfsdk:"icon"`
+	IsAPI           types.Bool     `tfsdk:"is_api"`
+	IsReference     types.Bool     `tfsdk:"is_reference"`
+	LinkCheck       types.String   `tfsdk:"link_check"`
+	LinkExternal    types.Bool     `tfsdk:"link_external"`
+	LinkURL         types.String   `tfsdk:"link_url"`
+	Error           types.Object   `tfsdk:"error"`
+	Metadata        types.Object   `tfsdk:"metadata"`
+	Next            types.Object   `tfsdk:"next"`
+	ParentDoc       types.String   `tfsdk:"parent_doc"`
+	ParentDocSlug   types.String   `tfsdk:"parent_doc_slug"`
+	Path            types.String   `tfsdk:"path"`
+	Order           types.Int64    `tfsdk:"order"`
+	PreviousSlug    types.String   `tfsdk:"previous_slug"`
+	Project         types.String   `tfsdk:"project"`
+	Revision        types.Int64    `tfsdk:"revision"`
+	Slug            types.String   `tfsdk:"slug"`
+	SlugUpdatedAt   types.String   `tfsdk:"slug_updated_at"`
+	SnippetDirs     types.List     `tfsdk:"snippet_dirs"`
+	SyncUnique      types.String   `tfsdk:"sync_unique"`
+	TemplateVars    types.Map      `tfsdk:"template_vars"`
+	Title           types.String   `tfsdk:"title"`
+	Type            types.String   `tfsdk:"type"`
+	UpdatedAt       types.String   `tfsdk:"updated_at"`
+	User            types.String   `tfsdk:"user"`
+	UseSlug         types.String   `tfsdk:"use_slug"`
+	VerifyParentDoc types.Bool     `tfsdk:"verify_parent_doc"`
+	Version         types.String   `tfsdk:"version"`
+	VersionID       types.String   `tfsdk:"version_id"`
 }
 
 // docMetadata represents the metadata field in the doc schema.
@@ -61,6 +70,18 @@ type docMetadata struct {
 	Description string   `tfsdk:"description"`
 }
 
+// docErrorPlan represents the error field in a doc plan.
+type docErrorPlan struct {
+	Code types.String `tfsdk:"code"`
+}
+
+// docMetadataPlan represents the metadata field in a doc plan, where values may be unknown.
+type docMetadataPlan struct {
+	Description types.String `tfsdk:"description"`
+	Image       types.List   `tfsdk:"image"`
+	Title       types.String `tfsdk:"title"`
+}
+
 // docModelValue returns a docModel value with the fields mapped from the `doc` parameter.
 //
 // This is used by both the data source and resource to create a plan and state value.
@@ -75,15 +96,37 @@ func docModelValue(ctx context.Context, doc readme.Doc, model docModel) docModel
 		model.ParentDocSlug = types.StringValue("")
 	}
 
-	bodyClean := strings.TrimSpace(doc.Body)
-	bodyClean = strings.ReplaceAll(bodyClean, `\n`, "\n")
+	bodyClean := types.StringValue(strings.ReplaceAll(strings.TrimSpace(doc.Body), `\n`, "\n"))
+	bodyHTML := types.StringValue(doc.BodyHTML)
+
+	// Only the hash of a body file is kept in the state.
+	if !model.BodyFile.IsNull() {
+		bodyClean = types.StringNull()
+		bodyHTML = types.StringNull()
+	}
+
+	// The path is only resolved by the data source when it looks up a doc in its category.
+	docPath := model.Path
+	if docPath.IsUnknown() {
+		docPath = types.StringNull()
+	}
+
+	// The provider records the previous slug when it changes the slug, which the API doesn't
+	// always return.
+	previousSlug := types.StringValue(doc.PreviousSlug)
+	if doc.PreviousSlug == "" && model.PreviousSlug.ValueString() != "" {
+		previousSlug = model.PreviousSlug
+	}
 
 	return docModel{
 		Algolia:         docModelAlgoliaValue(doc.Algolia),
 		API:             docModelAPIValue(doc.API),
-		Body:            model.Body,
-		BodyClean:       types.StringValue(bodyClean),
-		BodyHTML:        types.StringValue(doc.BodyHTML),
+		Body:            stateBody(model.Body, doc.Body, model.BodyFile, model.TemplateVars, model.SnippetDirs),
+		BodyClean:       bodyClean,
+		BodyFile:        model.BodyFile,
+		BodyHash:        model.BodyHash,
+		BodyHTML:        bodyHTML,
+		BodyRendered:    model.BodyRendered,
 		Category:        types.StringValue(doc.Category),
 		CategorySlug:    model.CategorySlug,
 		CreatedAt:       types.StringValue(doc.CreatedAt),
@@ -95,6 +138,7 @@ func docModelValue(ctx context.Context, doc readme.Doc, model docModel) docModel
 		Icon:            types.StringValue(doc.Icon),
 		IsAPI:           types.BoolValue(doc.IsAPI),
 		IsReference:     types.BoolValue(doc.IsReference),
+		LinkCheck:       model.LinkCheck,
 		LinkExternal:    types.BoolValue(doc.LinkExternal),
 		LinkURL:         types.StringValue(doc.LinkURL),
 		Metadata:        docModelMetadataValue(doc.Metadata),
@@ -102,12 +146,15 @@ func docModelValue(ctx context.Context, doc readme.Doc, model docModel) docModel
 		Order:           types.Int64Value(int64(doc.Order)),
 		ParentDoc:       types.StringValue(doc.ParentDoc),
 		ParentDocSlug:   model.ParentDocSlug,
-		PreviousSlug:    types.StringValue(doc.PreviousSlug),
+		Path:            docPath,
+		PreviousSlug:    previousSlug,
 		Project:         types.StringValue(doc.Project),
 		Revision:        types.Int64Value(int64(doc.Revision)),
 		Slug:            types.StringValue(doc.Slug),
 		SlugUpdatedAt:   types.StringValue(doc.SlugUpdatedAt),
+		SnippetDirs:     model.SnippetDirs,
 		SyncUnique:      types.StringValue(doc.SyncUnique),
+		TemplateVars:    model.TemplateVars,
 		Title:           types.StringValue(doc.Title),
 		Type:            types.StringValue(doc.Type),
 		UpdatedAt:       types.StringValue(doc.UpdatedAt),
@@ -119,15 +166,83 @@ func docModelValue(ctx context.Context, doc readme.Doc, model docModel) docModel
 	}
 }
 
+// docWriteParams adds the fields to `readme.DocParams` that the API accepts but the API client
+// doesn't support.
+//
+// The optional fields are pointers so they're only sent when they're set.
+type docWriteParams struct {
+	readme.DocParams
+	Deprecated   *bool              `json:"deprecated,omitempty"`
+	Excerpt      *string            `json:"excerpt,omitempty"`
+	Icon         *string            `json:"icon,omitempty"`
+	LinkExternal *bool              `json:"link_external,omitempty"`
+	LinkURL      *string            `json:"link_url,omitempty"`
+	Metadata     *docMetadataParams `json:"metadata,omitempty"`
+	Slug         string             `json:"slug,omitempty"`
+}
+
+// docMetadataParams represents the `metadata` field when creating or updating a doc.
+type docMetadataParams struct {
+	Description string   `json:"description"`
+	Image       []string `json:"image"`
+	Title       string   `json:"title"`
+}
+
+// saveDoc creates a doc if `slug` is empty, otherwise it updates the doc with that slug.
+//
+// If `newSlug` is set and differs from `slug`, the doc's slug is set to it. The API client doesn't
+// support the slug or the other fields in `docWriteParams`, so the request is made directly.
+func saveDoc(
+	client *readme.Client,
+	slug, newSlug string,
+	params docWriteParams,
+	options readme.RequestOptions,
+) (readme.Doc, *readme.APIResponse, error) {
+	params.Slug = ""
+	if newSlug != slug {
+		params.Slug = newSlug
+	}
+
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return readme.Doc{}, nil, fmt.Errorf("unable to marshal request: %w", err)
+	}
+
+	response := readme.Doc{}
+	request := &readme.APIRequest{
+		Endpoint:       readme.DocEndpoint,
+		Headers:        []readme.RequestHeader{{"Content-Type": "application/json"}},
+		Method:         "POST",
+		OkStatusCode:   []int{201},
+		Payload:        payload,
+		RequestOptions: options,
+		Response:       &response,
+		UseAuth:        true,
+	}
+
+	if slug != "" {
+		request.Endpoint = fmt.Sprintf("%s/%s", readme.DocEndpoint, slug)
+		request.Method = "PUT"
+		request.OkStatusCode = []int{200}
+	}
+
+	apiResponse, err := client.APIRequest(request)
+
+	return response, apiResponse, err
+}
+
 // getDoc retrieves a doc and returns the Terraform data source or resource.
 //
 // The `model` parameter represents a `docModel` that is merged with the response model.
 // This should include fields/attributes that are not in the API response, such as the
 // "slug" fields and "version" field.
 //
+// Versions, categories, and parent docs are resolved using the `lookups` cache, which may be nil.
+//
 // It returns a `docModel` for use in a plan or state.
 func getDoc(
 	client *readme.Client,
+	lookups *lookupCache,
 	ctx context.Context,
 	slug string,
 	model docModel,
@@ -138,11 +253,12 @@ func getDoc(
 	// Get the doc from ReadMe.
 	response, apiResponse, err := client.Doc.Get(slug, options)
 	if err != nil {
-		return state, apiResponse, fmt.Errorf(clientError(err, apiResponse))
+		return state, apiResponse, errors.New(clientError(err, apiResponse))
 	}
 
 	// Map the API object to the Terraform model.
 	state = docModelValue(ctx, response, model)
+	lookups.set(lookupDoc, response.ID, response.Slug)
 
 	// Resolve the 'version' attribute if it's not set.
 	if state.Version.ValueString() == "" && state.VersionID.ValueString() != "" {
@@ -151,7 +267,7 @@ func getDoc(
 			fmt.Sprintf("resolving version for version_id %s", state.VersionID.ValueString()),
 		)
 		state.Version = types.StringValue(
-			versionClean(ctx, client, state.VersionID.ValueString()),
+			lookups.versionClean(ctx, client, state.VersionID.ValueString()),
 		)
 	}
 
@@ -162,14 +278,11 @@ func getDoc(
 			fmt.Sprintf("resolving category_slug for category %s", state.Category.ValueString()),
 		)
 
-		category, apiResponse, err := client.Category.Get(
-			IDPrefix+state.Category.ValueString(),
-			options,
-		)
+		categorySlug, apiResponse, err := lookups.categorySlug(client, state.Category.ValueString(), options)
 		if err != nil {
 			return state, apiResponse, errors.New(clientError(err, apiResponse))
 		}
-		state.CategorySlug = types.StringValue(category.Slug)
+		state.CategorySlug = types.StringValue(categorySlug)
 	}
 
 	// Resolve the 'parent_doc_slug' attribute if 'parent_doc' is set.
@@ -183,11 +296,11 @@ func getDoc(
 				),
 			)
 
-			parent, apiResponse, err := client.Doc.Get(IDPrefix+state.ParentDoc.ValueString(), options)
+			parentSlug, apiResponse, err := lookups.docSlug(client, state.ParentDoc.ValueString(), options)
 			if err != nil {
 				return state, apiResponse, errors.New(clientError(err, apiResponse))
 			}
-			state.ParentDocSlug = types.StringValue(parent.Slug)
+			state.ParentDocSlug = types.StringValue(parentSlug)
 		}
 	}
 
@@ -408,7 +521,7 @@ func docModelMetadataValue(metadata readme.DocMetadata) basetypes.ObjectValue {
 	}
 
 	// Return a null object if the metadata is empty.
-	if metadata.Description == "" {
+	if metadata.Title == "" && metadata.Description == "" && len(images) == 0 {
 		return types.ObjectNull(metadataTypes)
 	}
 
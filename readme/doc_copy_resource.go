@@ -0,0 +1,842 @@
+package readme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+// docCopyConcurrency is the number of source docs retrieved at once.
+const docCopyConcurrency = 4
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &docCopyResource{}
+	_ resource.ResourceWithConfigure  = &docCopyResource{}
+	_ resource.ResourceWithModifyPlan = &docCopyResource{}
+)
+
+// docCopyResource is the resource implementation.
+type docCopyResource struct {
+	client  *readme.Client
+	config  providerConfig
+	locks   *categoryLocks
+	lookups *lookupCache
+}
+
+// docCopyModel is the resource model.
+type docCopyModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Docs                types.Map    `tfsdk:"docs"`
+	IncludeChildren     types.Bool   `tfsdk:"include_children"`
+	Slug                types.String `tfsdk:"slug"`
+	SourceHash          types.String `tfsdk:"source_hash"`
+	SourceVersion       types.String `tfsdk:"source_version"`
+	Sync                types.Bool   `tfsdk:"sync"`
+	TargetCategorySlug  types.String `tfsdk:"target_category_slug"`
+	TargetParentDocSlug types.String `tfsdk:"target_parent_doc_slug"`
+	TargetVersion       types.String `tfsdk:"target_version"`
+}
+
+// docCopyDocModel is a doc copied to the target version.
+type docCopyDocModel struct {
+	ID            types.String `tfsdk:"id"`
+	Slug          types.String `tfsdk:"slug"`
+	ParentDocSlug types.String `tfsdk:"parent_doc_slug"`
+	Created       types.Bool   `tfsdk:"created"`
+	Hash          types.String `tfsdk:"hash"`
+}
+
+// docCopyDocAttrTypes are the attribute types of a doc in the `docs` attribute.
+var docCopyDocAttrTypes = map[string]attr.Type{
+	"id":              types.StringType,
+	"slug":            types.StringType,
+	"parent_doc_slug": types.StringType,
+	"created":         types.BoolType,
+	"hash":            types.StringType,
+}
+
+// docCopySource is a source doc and what it's copied as in the target version.
+type docCopySource struct {
+	doc readme.Doc
+	// params are the parameters to create or update the target doc, with the parent mapped to its
+	// slug in the target version.
+	params docWriteParams
+	hash   string
+}
+
+// NewDocCopyResource is a helper function to simplify the provider implementation.
+func NewDocCopyResource() resource.Resource {
+	return &docCopyResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *docCopyResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_doc_copy"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *docCopyResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	_ *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg := req.ProviderData.(*providerData)
+	r.client = cfg.client
+	r.config = cfg.config
+	r.locks = cfg.locks
+	r.lookups = cfg.lookups
+}
+
+// Schema defines the schema for the resource.
+func (r *docCopyResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Copy a doc and optionally its children from one version to another on ReadMe.com\n\n" +
+			"This is useful to promote a doc to a newer version or to backport a fix to an older version. " +
+			"The doc is copied to the same category slug in the target version unless " +
+			"`target_category_slug` is set, and each child doc is copied under its copied parent.\n\n" +
+			"A doc that already exists in the target version with the same slug is updated. Otherwise, it's " +
+			"created. Destroying this resource deletes the docs it created and leaves the docs it updated.\n\n" +
+			"The docs are copied when the resource is created or its attributes change. When `sync` is " +
+			"`true`, the source docs are checked when planning and copied again when they change.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The target version and slug of the doc, separated by `/`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"docs": schema.MapNestedAttribute{
+				Description: "The docs copied to the target version, keyed by the slug of the source doc.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the target doc.",
+							Computed:    true,
+						},
+						"slug": schema.StringAttribute{
+							Description: "The slug of the target doc.",
+							Computed:    true,
+						},
+						"parent_doc_slug": schema.StringAttribute{
+							Description: "The slug of the target doc's parent. This is empty for a doc without a parent.",
+							Computed:    true,
+						},
+						"created": schema.BoolAttribute{
+							Description: "Whether the target doc was created by this resource. Only created docs " +
+								"are deleted when the resource is destroyed.",
+							Computed: true,
+						},
+						"hash": schema.StringAttribute{
+							Description: "The SHA-256 hash of the copied doc.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"include_children": schema.BoolAttribute{
+				Description: "Copy the child docs of the doc at any depth. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"slug": schema.StringAttribute{
+				Description: "The slug of the doc to copy.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_hash": schema.StringAttribute{
+				Description: "The SHA-256 hash of the source docs when they were copied.",
+				Computed:    true,
+			},
+			"source_version": schema.StringAttribute{
+				Description: "The version to copy the doc from. Defaults to the provider's " +
+					"`config.default_version` if set, otherwise the project's stable version.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sync": schema.BoolAttribute{
+				Description: "Copy the docs again when the source docs change. The source docs are " +
+					"retrieved each time the resource is planned. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"target_category_slug": schema.StringAttribute{
+				Description: "The category slug to copy the doc to. Defaults to the slug of the source " +
+					"doc's category, which must exist in the target version.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_parent_doc_slug": schema.StringAttribute{
+				Description: "The slug of the parent doc to copy the doc under. Defaults to the slug of the " +
+					"source doc's parent, which must exist in the target version.",
+				Optional: true,
+			},
+			"target_version": schema.StringAttribute{
+				Description: "The version to copy the doc to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan applies the default source version and checks the source docs for changes.
+//
+// The copied docs keep their state unless an attribute that changes the copy is updated, or
+// `sync` is true and the source docs changed.
+func (r *docCopyResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Skip when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config docCopyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Use the provider's default version if it's not set.
+	if config.SourceVersion.IsNull() && plan.SourceVersion.IsUnknown() {
+		plan.SourceVersion = types.StringNull()
+		if r.config.DefaultVersion.ValueString() != "" {
+			plan.SourceVersion = r.config.DefaultVersion
+		}
+	}
+
+	if !plan.SourceVersion.IsUnknown() && !plan.TargetVersion.IsUnknown() &&
+		cleanVersion(plan.SourceVersion.ValueString()) == cleanVersion(plan.TargetVersion.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("target_version"),
+			"Invalid target version.",
+			"The target version must be different from the source version.",
+		)
+
+		return
+	}
+
+	if !plan.TargetVersion.IsUnknown() && !plan.Slug.IsUnknown() {
+		plan.ID = docCopyID(plan)
+	}
+
+	if req.State.Raw.IsNull() {
+		plan.Docs = types.MapUnknown(types.ObjectType{AttrTypes: docCopyDocAttrTypes})
+		plan.SourceHash = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+
+		return
+	}
+
+	var state docCopyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Docs = state.Docs
+	plan.SourceHash = state.SourceHash
+
+	// The docs are copied again when what's copied or where it's copied to changes.
+	if !plan.IncludeChildren.Equal(state.IncludeChildren) ||
+		!plan.TargetParentDocSlug.Equal(state.TargetParentDocSlug) {
+		plan.Docs = types.MapUnknown(types.ObjectType{AttrTypes: docCopyDocAttrTypes})
+		plan.SourceHash = types.StringUnknown()
+	} else if plan.Sync.ValueBool() && !plan.SourceVersion.IsUnknown() && !plan.TargetCategorySlug.IsUnknown() {
+		sources, ok := r.sources(ctx, plan, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+
+		if hash := docCopySourceHash(sources); hash != state.SourceHash.ValueString() {
+			tflog.Info(ctx, fmt.Sprintf("source docs for %s changed, copying again", plan.Slug))
+			plan.Docs = types.MapUnknown(types.ObjectType{AttrTypes: docCopyDocAttrTypes})
+			plan.SourceHash = types.StringValue(hash)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create copies the docs and sets the initial Terraform state.
+func (r *docCopyResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "doc copy") {
+		return
+	}
+
+	var plan docCopyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.copy(ctx, plan, nil, &resp.State, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+//
+// Target docs that no longer exist are removed from the state, and the resource is removed if the
+// copy of the doc itself no longer exists.
+func (r *docCopyResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state docCopyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	docs := map[string]docCopyDocModel{}
+	resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &docs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestOpts := apiRequestOptions(state.TargetVersion)
+
+	for source, doc := range docs {
+		response, apiResponse, err := r.client.Doc.Get(doc.Slug.ValueString(), requestOpts)
+		if err != nil {
+			if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == http.StatusNotFound {
+				tflog.Info(ctx, fmt.Sprintf("copy of doc %s not found, removing from state", source))
+				delete(docs, source)
+
+				continue
+			}
+
+			resp.Diagnostics.Append(clientDiagnostic(
+				fmt.Sprintf("Unable to retrieve the copy of doc %s.", source),
+				err,
+				apiResponse,
+				apiErrorPaths{"DOC_NOTFOUND": path.Empty()},
+			))
+
+			return
+		}
+
+		doc.ID = types.StringValue(response.ID)
+		docs[source] = doc
+	}
+
+	if _, ok := docs[state.Slug.ValueString()]; !ok {
+		tflog.Info(ctx, fmt.Sprintf("copy of doc %s not found, removing doc copy from state", state.Slug))
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	state.Docs = docCopyDocsValue(ctx, docs, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update copies the docs again when they're planned to change and sets the updated Terraform
+// state on success.
+func (r *docCopyResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "update", "doc copy") {
+		return
+	}
+
+	var plan, state docCopyModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	prior := map[string]docCopyDocModel{}
+	resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only `sync` changed, so there's nothing to copy.
+	if !plan.Docs.IsUnknown() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+
+		return
+	}
+
+	r.copy(ctx, plan, prior, &resp.State, &resp.Diagnostics)
+}
+
+// Delete deletes the docs created by the resource and removes the Terraform state on success.
+//
+// Target docs that existed before they were copied are left in place.
+func (r *docCopyResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "delete", "doc copy") {
+		return
+	}
+
+	var state docCopyModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	docs := map[string]docCopyDocModel{}
+	resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &docs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := r.locks.lock(ctx, r.categoryLockKey(ctx, state, docs))
+	defer unlock()
+	defer r.lookups.invalidate(lookupDoc)
+
+	for _, source := range docCopyDeleteOrder(docs) {
+		if !r.deleteDoc(ctx, source, docs[source], state.TargetVersion, &resp.Diagnostics) {
+			return
+		}
+	}
+}
+
+// categoryLockKey returns the lock key of the target category. When `target_category_slug` isn't
+// set, the category is resolved from the target doc of the source doc. An empty key is returned if
+// the target doc can't be retrieved.
+func (r *docCopyResource) categoryLockKey(
+	ctx context.Context,
+	state docCopyModel,
+	docs map[string]docCopyDocModel,
+) string {
+	version := state.TargetVersion.ValueString()
+	if state.TargetCategorySlug.ValueString() != "" {
+		return categoryLockKey(version, state.TargetCategorySlug.ValueString())
+	}
+
+	root, ok := docs[state.Slug.ValueString()]
+	if !ok {
+		return ""
+	}
+
+	requestOpts := apiRequestOptions(state.TargetVersion)
+
+	doc, apiResponse, err := r.client.Doc.Get(root.Slug.ValueString(), requestOpts)
+	if err != nil {
+		tflog.Info(ctx, fmt.Sprintf("unable to retrieve target doc for lock: %s", clientError(err, apiResponse)))
+
+		return ""
+	}
+
+	slug, apiResponse, err := r.lookups.categorySlug(r.client, doc.Category, requestOpts)
+	if err != nil {
+		tflog.Info(ctx, fmt.Sprintf(
+			"unable to resolve category slug for lock, using category ID: %s",
+			clientError(err, apiResponse),
+		))
+
+		return categoryLockKey(version, IDPrefix+doc.Category)
+	}
+
+	return categoryLockKey(version, slug)
+}
+
+// copy creates or updates the target doc for each source doc, parents before their children, and
+// deletes the created docs whose source docs are no longer copied. The state is saved with the
+// docs that were copied, even if an error occurs.
+func (r *docCopyResource) copy(
+	ctx context.Context,
+	plan docCopyModel,
+	prior map[string]docCopyDocModel,
+	state stateSetter,
+	diags *diag.Diagnostics,
+) {
+	sources, ok := r.sources(ctx, plan, diags)
+	if !ok {
+		return
+	}
+
+	hash := docCopySourceHash(sources)
+	if !plan.SourceHash.IsUnknown() && plan.SourceHash.ValueString() != hash {
+		diags.AddError(
+			"Source docs changed.",
+			fmt.Sprintf("The source docs for %s changed after the plan was created. Run the plan again.", plan.Slug),
+		)
+
+		return
+	}
+
+	// The copied docs begin with the prior docs since they exist until they're deleted.
+	copied := make(map[string]docCopyDocModel, len(prior))
+	for source, doc := range prior {
+		copied[source] = doc
+	}
+
+	defer func() {
+		plan.ID = docCopyID(plan)
+		plan.SourceHash = types.StringValue(hash)
+		plan.Docs = docCopyDocsValue(ctx, copied, diags)
+		diags.Append(state.Set(ctx, plan)...)
+	}()
+
+	unlock := r.locks.lock(ctx, categoryLockKey(plan.TargetVersion.ValueString(), sources[0].params.CategorySlug))
+	defer unlock()
+	defer r.lookups.invalidate(lookupDoc)
+
+	requestOpts := apiRequestOptions(plan.TargetVersion)
+
+	// The slugs of the copied docs by their source slug, to resolve the parent of a child doc.
+	slugs := map[string]string{}
+	for i, source := range sources {
+		params := source.params
+		if i > 0 {
+			params.ParentDocSlug = slugs[params.ParentDocSlug]
+		}
+
+		existing, ok := copied[source.doc.Slug]
+		if !ok {
+			existing, ok = r.existingDoc(source.doc.Slug, requestOpts, diags)
+			if !ok {
+				return
+			}
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("copying doc %s to version %s", source.doc.Slug, plan.TargetVersion))
+
+		response, apiResponse, err := saveDoc(r.client, existing.Slug.ValueString(), source.doc.Slug, params, requestOpts)
+		if err != nil {
+			diags.Append(clientDiagnostic(
+				fmt.Sprintf("Unable to copy doc %s.", source.doc.Slug),
+				err,
+				apiResponse,
+				apiErrorPaths{
+					"DOC_NOTFOUND":      path.Empty(),
+					"CATEGORY_NOTFOUND": path.Root("target_category_slug"),
+					"VERSION_NOTFOUND":  path.Root("target_version"),
+				},
+			))
+
+			return
+		}
+
+		copied[source.doc.Slug] = docCopyDocModel{
+			ID:            types.StringValue(response.ID),
+			Slug:          types.StringValue(response.Slug),
+			ParentDocSlug: types.StringValue(params.ParentDocSlug),
+			Created:       existing.Created,
+			Hash:          types.StringValue(source.hash),
+		}
+		slugs[source.doc.Slug] = response.Slug
+	}
+
+	// Delete the created docs that are no longer copied, children before their parents.
+	removed := map[string]docCopyDocModel{}
+	for source, doc := range copied {
+		if _, ok := slugs[source]; !ok {
+			removed[source] = doc
+		}
+	}
+
+	for _, source := range docCopyDeleteOrder(removed) {
+		if !r.deleteDoc(ctx, source, removed[source], plan.TargetVersion, diags) {
+			return
+		}
+		delete(copied, source)
+	}
+}
+
+// sources retrieves the source doc and its children when `include_children` is true, parents
+// before their children.
+func (r *docCopyResource) sources(
+	ctx context.Context,
+	plan docCopyModel,
+	diags *diag.Diagnostics,
+) ([]docCopySource, bool) {
+	requestOpts := apiRequestOptions(plan.SourceVersion)
+
+	root, apiResponse, err := r.client.Doc.Get(plan.Slug.ValueString(), requestOpts)
+	if err != nil {
+		diags.Append(clientDiagnostic(
+			"Unable to retrieve the source doc.",
+			err,
+			apiResponse,
+			apiErrorPaths{"DOC_NOTFOUND": path.Root("slug"), "VERSION_NOTFOUND": path.Root("source_version")},
+		))
+
+		return nil, false
+	}
+
+	sourceCategorySlug, apiResponse, err := r.lookups.categorySlug(r.client, root.Category, requestOpts)
+	if err != nil {
+		diags.Append(clientDiagnostic("Unable to resolve the source doc's category.", err, apiResponse, nil))
+
+		return nil, false
+	}
+
+	categorySlug := sourceCategorySlug
+	if plan.TargetCategorySlug.ValueString() != "" {
+		categorySlug = plan.TargetCategorySlug.ValueString()
+	}
+
+	parentSlug := plan.TargetParentDocSlug.ValueString()
+	if plan.TargetParentDocSlug.IsNull() && root.ParentDoc != "" {
+		parentSlug, apiResponse, err = r.lookups.docSlug(r.client, root.ParentDoc, requestOpts)
+		if err != nil {
+			diags.Append(clientDiagnostic("Unable to resolve the source doc's parent.", err, apiResponse, nil))
+
+			return nil, false
+		}
+	}
+
+	sources := []docCopySource{newDocCopySource(root, categorySlug, parentSlug)}
+	if !plan.IncludeChildren.ValueBool() {
+		return sources, true
+	}
+
+	docs, apiResponse, err := r.client.Category.GetDocs(sourceCategorySlug, requestOpts)
+	if err != nil {
+		diags.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to retrieve the docs in category %s.", sourceCategorySlug),
+			err,
+			apiResponse,
+			nil,
+		))
+
+		return nil, false
+	}
+
+	node, ok := findCategoryDoc(docs, root.ID, root.Slug)
+	if !ok {
+		return sources, true
+	}
+
+	descendants := categoryDocDescendants(node.doc)
+	children := make([]readme.Doc, len(descendants))
+
+	errs := forEachConcurrent(ctx, len(descendants), docCopyConcurrency, func(i int) error {
+		doc, apiResponse, err := r.client.Doc.Get(descendants[i].doc.Slug, requestOpts)
+		if err != nil {
+			return errors.New(clientError(err, apiResponse))
+		}
+
+		children[i] = doc
+
+		return nil
+	})
+
+	for i, descendant := range descendants {
+		if errs[i] != nil {
+			diags.AddError(
+				"Unable to retrieve a source doc.",
+				fmt.Sprintf("Unable to retrieve the child doc %s: %s", descendant.doc.Slug, errs[i]),
+			)
+
+			continue
+		}
+
+		sources = append(sources, newDocCopySource(children[i], categorySlug, descendant.parentSlug))
+	}
+
+	return sources, !diags.HasError()
+}
+
+// existingDoc returns the doc in the target version with the slug, or an empty doc marked as
+// created if it doesn't exist.
+func (r *docCopyResource) existingDoc(
+	slug string,
+	options readme.RequestOptions,
+	diags *diag.Diagnostics,
+) (docCopyDocModel, bool) {
+	doc, apiResponse, err := r.client.Doc.Get(slug, options)
+	if err != nil {
+		if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == http.StatusNotFound {
+			return docCopyDocModel{Created: types.BoolValue(true)}, true
+		}
+
+		diags.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to check if doc %s exists in the target version.", slug),
+			err,
+			apiResponse,
+			apiErrorPaths{"DOC_NOTFOUND": path.Empty(), "VERSION_NOTFOUND": path.Root("target_version")},
+		))
+
+		return docCopyDocModel{}, false
+	}
+
+	return docCopyDocModel{Slug: types.StringValue(doc.Slug), Created: types.BoolValue(false)}, true
+}
+
+// deleteDoc deletes a target doc if the resource created it. A doc that doesn't exist is ignored.
+func (r *docCopyResource) deleteDoc(
+	ctx context.Context,
+	source string,
+	doc docCopyDocModel,
+	version types.String,
+	diags *diag.Diagnostics,
+) bool {
+	if !doc.Created.ValueBool() {
+		tflog.Info(ctx, fmt.Sprintf("leaving doc %s since it existed before it was copied", doc.Slug))
+
+		return true
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("deleting copy of doc %s", source))
+
+	_, apiResponse, err := r.client.Doc.Delete(doc.Slug.ValueString(), apiRequestOptions(version))
+	if err != nil {
+		if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == http.StatusNotFound {
+			return true
+		}
+
+		diags.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to delete the copy of doc %s.", source),
+			err,
+			apiResponse,
+			apiErrorPaths{"DOC_NOTFOUND": path.Empty()},
+		))
+
+		return false
+	}
+
+	return true
+}
+
+// newDocCopySource returns a source doc with the parameters to copy it to the category under the
+// parent doc.
+func newDocCopySource(doc readme.Doc, categorySlug, parentSlug string) docCopySource {
+	images := []string{}
+	for _, image := range doc.Metadata.Image {
+		images = append(images, fmt.Sprintf("%v", image))
+	}
+
+	params := docWriteParams{
+		DocParams: readme.DocParams{
+			Body:          doc.Body,
+			CategorySlug:  categorySlug,
+			Error:         doc.Error,
+			Hidden:        &doc.Hidden,
+			Order:         intPoint(doc.Order),
+			ParentDocSlug: parentSlug,
+			Title:         doc.Title,
+			Type:          doc.Type,
+		},
+		Deprecated:   &doc.Deprecated,
+		Excerpt:      &doc.Excerpt,
+		Icon:         &doc.Icon,
+		LinkExternal: &doc.LinkExternal,
+		LinkURL:      &doc.LinkURL,
+		Metadata: &docMetadataParams{
+			Description: doc.Metadata.Description,
+			Image:       images,
+			Title:       doc.Metadata.Title,
+		},
+	}
+
+	// The parameters are always encodable, so the error is ignored.
+	payload, _ := json.Marshal(params)
+	sum := sha256.Sum256(payload)
+
+	return docCopySource{doc: doc, params: params, hash: hex.EncodeToString(sum[:])}
+}
+
+// docCopyID returns the ID of the copy, the target version and the slug of the source doc.
+func docCopyID(plan docCopyModel) types.String {
+	return types.StringValue(plan.TargetVersion.ValueString() + "/" + plan.Slug.ValueString())
+}
+
+// docCopySourceHash returns the SHA-256 hash of the source docs in the order they're copied.
+func docCopySourceHash(sources []docCopySource) string {
+	hash := sha256.New()
+	for _, source := range sources {
+		hash.Write([]byte(source.doc.Slug + ":" + source.hash + "\n"))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// docCopyDeleteOrder returns the source slugs of the copied docs ordered so that children are
+// deleted before their parents.
+func docCopyDeleteOrder(docs map[string]docCopyDocModel) []string {
+	parents := map[string]string{}
+	for _, doc := range docs {
+		parents[doc.Slug.ValueString()] = doc.ParentDocSlug.ValueString()
+	}
+
+	depth := func(slug string) int {
+		count := 0
+		for parent, ok := parents[slug]; ok && parent != "" && count < len(parents); parent, ok = parents[parent] {
+			count++
+		}
+
+		return count
+	}
+
+	sources := make([]string, 0, len(docs))
+	for source := range docs {
+		sources = append(sources, source)
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		di, dj := depth(docs[sources[i]].Slug.ValueString()), depth(docs[sources[j]].Slug.ValueString())
+		if di != dj {
+			return di > dj
+		}
+
+		return sources[i] < sources[j]
+	})
+
+	return sources
+}
+
+// docCopyDocsValue converts the copied docs to a map value for the `docs` attribute.
+func docCopyDocsValue(
+	ctx context.Context,
+	docs map[string]docCopyDocModel,
+	diags *diag.Diagnostics,
+) types.Map {
+	value, valueDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: docCopyDocAttrTypes}, docs)
+	diags.Append(valueDiags...)
+
+	return value
+}
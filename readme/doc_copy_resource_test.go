@@ -0,0 +1,253 @@
+package readme
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestNewDocCopySource(t *testing.T) {
+	doc := readme.Doc{
+		Body:     "The body.",
+		Category: "source-category-id",
+		Excerpt:  "An excerpt.",
+		Hidden:   true,
+		Metadata: readme.DocMetadata{Title: "Meta", Image: []any{"https://example.com/image.png"}},
+		Order:    3,
+		Slug:     "a-doc",
+		Title:    "A Doc",
+		Type:     "basic",
+	}
+
+	source := newDocCopySource(doc, "guides", "parent")
+	params := source.params
+
+	if params.CategorySlug != "guides" || params.ParentDocSlug != "parent" || params.Category != "" {
+		t.Errorf("expected the doc to be copied to the category and parent slugs, got %+v", params.DocParams)
+	}
+	if params.Body != doc.Body || params.Title != doc.Title || !*params.Hidden || *params.Order != 3 {
+		t.Errorf("expected the doc fields to be copied, got %+v", params.DocParams)
+	}
+	if *params.Excerpt != doc.Excerpt || !reflect.DeepEqual(params.Metadata.Image, []string{"https://example.com/image.png"}) {
+		t.Errorf("expected the extra fields to be copied, got %+v", params)
+	}
+
+	if source.hash != newDocCopySource(doc, "guides", "parent").hash {
+		t.Error("expected the hash of the same doc to be stable")
+	}
+
+	doc.Body = "A changed body."
+	changed := newDocCopySource(doc, "guides", "parent")
+	if source.hash == changed.hash {
+		t.Error("expected the hash to change when the doc changes")
+	}
+	if docCopySourceHash([]docCopySource{source}) == docCopySourceHash([]docCopySource{changed}) {
+		t.Error("expected the source hash to change when a doc changes")
+	}
+}
+
+func TestDocCopyDeleteOrder(t *testing.T) {
+	doc := func(slug, parent string) docCopyDocModel {
+		return docCopyDocModel{Slug: types.StringValue(slug), ParentDocSlug: types.StringValue(parent)}
+	}
+
+	docs := map[string]docCopyDocModel{
+		"root":       doc("root", "outside"),
+		"child":      doc("child", "root"),
+		"grandchild": doc("grandchild", "child"),
+		"sibling":    doc("sibling", "root"),
+	}
+
+	expect := []string{"grandchild", "child", "sibling", "root"}
+	if order := docCopyDeleteOrder(docs); !reflect.DeepEqual(order, expect) {
+		t.Errorf("expected the delete order %v, got %v", expect, order)
+	}
+}
+
+func TestDocCopyResource(t *testing.T) {
+	defer gock.OffAll()
+
+	source := mockDoc
+	source.ParentDoc = ""
+	child := mockDoc
+	child.ID = "63b891d3ee384600680ce9ec"
+	child.ParentDoc = source.ID
+	child.Slug = "child-doc"
+	child.Title = "Child Doc"
+
+	copied := source
+	copied.ID = "73b891d3ee384600680ce9ea"
+	copiedChild := child
+	copiedChild.ID = "73b891d3ee384600680ce9ec"
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The doc is updated in the target version and its new child is created.
+			{
+				PreConfig: func() {
+					gock.OffAll()
+					// Source docs.
+					gock.New(testURL).
+						Get("/docs/"+source.Slug).
+						MatchHeader("x-readme-version", "1.0").
+						Persist().
+						Reply(200).
+						JSON(source)
+					gock.New(testURL).
+						Get("/docs/"+child.Slug).
+						MatchHeader("x-readme-version", "1.0").
+						Persist().
+						Reply(200).
+						JSON(child)
+					gock.New(testURL).
+						Get("/categories/" + mockCategory.ID).
+						Persist().
+						Reply(200).
+						JSON(mockCategory)
+					gock.New(testURL).
+						Get("/categories/"+mockCategory.Slug+"/docs").
+						MatchHeader("x-readme-version", "1.0").
+						Persist().
+						Reply(200).
+						JSON([]readme.CategoryDocs{
+							{ID: source.ID, Slug: source.Slug, Children: []readme.CategoryDocs{
+								{ID: child.ID, Slug: child.Slug},
+							}},
+						})
+					// Target docs.
+					gock.New(testURL).
+						Get("/docs/"+source.Slug).
+						MatchHeader("x-readme-version", "2.0").
+						Persist().
+						Reply(200).
+						JSON(copied)
+					gock.New(testURL).
+						Get("/docs/"+child.Slug).
+						MatchHeader("x-readme-version", "2.0").
+						Times(1).
+						Reply(404).
+						JSON(readme.APIErrorResponse{Error: "DOC_NOTFOUND"})
+					gock.New(testURL).
+						Put("/docs/"+source.Slug).
+						MatchHeader("x-readme-version", "2.0").
+						Times(1).
+						Reply(200).
+						JSON(copied)
+					gock.New(testURL).
+						Post("/docs").
+						MatchHeader("x-readme-version", "2.0").
+						BodyString(`"parentDocSlug":"` + source.Slug + `".*"slug":"` + child.Slug + `"`).
+						Times(1).
+						Reply(201).
+						JSON(copiedChild)
+					gock.New(testURL).
+						Get("/docs/"+child.Slug).
+						MatchHeader("x-readme-version", "2.0").
+						Persist().
+						Reply(200).
+						JSON(copiedChild)
+				},
+				Config: testProviderConfig + `
+					resource "readme_doc_copy" "test" {
+						slug             = "` + source.Slug + `"
+						source_version   = "1.0"
+						target_version   = "2.0"
+						include_children = true
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_doc_copy.test", "id", "2.0/"+source.Slug),
+					resource.TestCheckResourceAttr("readme_doc_copy.test", "docs.%", "2"),
+					resource.TestCheckResourceAttr("readme_doc_copy.test", "docs."+source.Slug+".id", copied.ID),
+					resource.TestCheckResourceAttr("readme_doc_copy.test", "docs."+source.Slug+".created", "false"),
+					resource.TestCheckResourceAttr("readme_doc_copy.test", "docs."+child.Slug+".id", copiedChild.ID),
+					resource.TestCheckResourceAttr("readme_doc_copy.test", "docs."+child.Slug+".created", "true"),
+					resource.TestCheckResourceAttr(
+						"readme_doc_copy.test", "docs."+child.Slug+".parent_doc_slug", source.Slug),
+					resource.TestCheckResourceAttrSet("readme_doc_copy.test", "source_hash"),
+				),
+			},
+			// Only the created child is deleted when the resource is destroyed.
+			{
+				PreConfig: func() {
+					gock.New(testURL).
+						Delete("/docs/"+child.Slug).
+						MatchHeader("x-readme-version", "2.0").
+						Times(1).
+						Reply(204)
+				},
+				Config: testProviderConfig + `
+					resource "readme_doc_copy" "test" {
+						slug             = "` + source.Slug + `"
+						source_version   = "1.0"
+						target_version   = "2.0"
+						include_children = true
+					}`,
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestDocCopyResource_CategoryLockKey(t *testing.T) {
+	defer gock.OffAll()
+
+	ctx := context.Background()
+	client, _ := readme.NewClient(testToken, testURL)
+	r := &docCopyResource{client: client, lookups: newLookupCache(time.Minute)}
+
+	state := docCopyModel{
+		Slug:               types.StringValue("source"),
+		TargetCategorySlug: types.StringValue("guides"),
+		TargetVersion:      types.StringValue("2.0"),
+	}
+	docs := map[string]docCopyDocModel{"source": {Slug: types.StringValue("target")}}
+
+	if key := r.categoryLockKey(ctx, state, docs); key != "2.0/guides" {
+		t.Errorf("expected the target category's key, got '%s'", key)
+	}
+
+	// The category is resolved from the target doc when the target category isn't set.
+	target := mockDoc
+	target.Slug = "target"
+	gock.New(testURL).Get("/docs/target").MatchHeader("x-readme-version", "2.0").Reply(200).JSON(target)
+	r.lookups.set(lookupCategory, target.Category, "documentation")
+
+	state.TargetCategorySlug = types.StringNull()
+	if key := r.categoryLockKey(ctx, state, docs); key != "2.0/documentation" {
+		t.Errorf("expected the target doc's category key, got '%s'", key)
+	}
+
+	// No lock is taken when the target doc can't be retrieved.
+	gock.New(testURL).Get("/docs/target").Reply(404).JSON(readme.APIErrorResponse{Error: "DOC_NOTFOUND"})
+	if key := r.categoryLockKey(ctx, state, docs); key != "" {
+		t.Errorf("expected no key, got '%s'", key)
+	}
+}
+
+func TestDocCopyResource_SameVersion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig + `
+					resource "readme_doc_copy" "test" {
+						slug           = "a-doc"
+						source_version = "v1.0"
+						target_version = "1.0"
+					}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must be different from the source version`),
+			},
+		},
+	})
+}
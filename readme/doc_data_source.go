@@ -9,17 +9,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/markdown"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &docDataSource{}
-	_ datasource.DataSourceWithConfigure = &docDataSource{}
+	_ datasource.DataSource                   = &docDataSource{}
+	_ datasource.DataSourceWithConfigure      = &docDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &docDataSource{}
 )
 
 // docDataSource is the data source implementation.
 type docDataSource struct {
-	client *readme.Client
+	client  *readme.Client
+	lookups *lookupCache
 }
 
 // NewDocDataSource is a helper function to simplify the provider implementation.
@@ -54,15 +57,28 @@ func (d *docDataSource) Read(
 	requestOpts := apiRequestOptions(state.Version)
 	tflog.Info(ctx, fmt.Sprintf("retrieving doc with request options=%+v", requestOpts))
 
+	// Find the doc's slug if it's identified by another attribute.
+	lookup, ok := d.lookupDoc(ctx, state, requestOpts, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	if lookup.categorySlug != "" {
+		state.CategorySlug = types.StringValue(lookup.categorySlug)
+	}
+	if state.Path.IsNull() && lookup.path != "" {
+		state.Path = types.StringValue(lookup.path)
+	}
+
 	// Get the doc.
-	state, _, err := getDoc(d.client, ctx, state.Slug.ValueString(), state, requestOpts)
+	state, apiResponse, err := getDoc(d.client, d.lookups, ctx, lookup.slug, state, requestOpts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to retrieve doc metadata.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to retrieve doc metadata.", err, apiResponse, nil))
 
 		return
 	}
 
-	state.Body = state.BodyClean
+	state.Body = markdown.Value{StringValue: state.BodyClean}
 
 	// Set state.
 	diags = resp.State.Set(ctx, &state)
@@ -72,6 +88,21 @@ func (d *docDataSource) Read(
 	}
 }
 
+// ValidateConfig checks that the doc is identified by exactly one attribute.
+func (d *docDataSource) ValidateConfig(
+	ctx context.Context,
+	req datasource.ValidateConfigRequest,
+	resp *datasource.ValidateConfigResponse,
+) {
+	var config docModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateDocLookup(config, &resp.Diagnostics)
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *docDataSource) Configure(
 	ctx context.Context,
@@ -82,7 +113,9 @@ func (d *docDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	cfg := req.ProviderData.(*providerData)
+	d.client = cfg.client
+	d.lookups = cfg.lookups
 }
 
 // Schema for the readme_doc data source.
@@ -95,6 +128,11 @@ func (d *docDataSource) Schema(
 	// resources and data sources.
 	resp.Schema = schema.Schema{
 		Description: "Retrieve docs on ReadMe.com\n\n" +
+			"The doc is looked up by one of `slug`, `id`, `title`, or `path`. Slugs change when docs are " +
+			"renamed in the web UI, so the other attributes may be more stable. Looking up a doc by `title` " +
+			"requires `category_slug`. A doc's `id`, `title`, or `path` is found by searching its category's " +
+			"docs, and the candidate docs are listed when the doc isn't found or the title matches more " +
+			"than one doc.\n\n" +
 			"See <https://docs.readme.com/main/reference/getdoc> for more information about this API endpoint.\n\n",
 		Attributes: map[string]schema.Attribute{
 			"algolia": schema.SingleNestedAttribute{
@@ -208,6 +246,7 @@ func (d *docDataSource) Schema(
 				},
 			},
 			"body": schema.StringAttribute{
+				CustomType:  markdown.Type{},
 				Description: "The body content of the doc, formatted in ReadMe or GitHub flavored Markdown.",
 				Computed:    true,
 			},
@@ -216,19 +255,37 @@ func (d *docDataSource) Schema(
 					"This is an alias for the `body` attribute.",
 				Computed: true,
 			},
+			// These aren't used by the doc data source, but must be present because the struct
+			// is shared with the doc resource, which does use them.
+			"body_file": schema.StringAttribute{
+				Description: "This is an unused attribute in the data source that is present to " +
+					"satisfy the model shared with the doc resource. It may be removed in the future.",
+				Computed: true,
+			},
+			"body_hash": schema.StringAttribute{
+				Description: "This is an unused attribute in the data source that is present to " +
+					"satisfy the model shared with the doc resource. It may be removed in the future.",
+				Computed: true,
+			},
 			"body_html": schema.StringAttribute{
 				Description: "The body content in HTML.",
 				Computed:    true,
 			},
+			"body_rendered": schema.StringAttribute{
+				Description: "This is an unused attribute in the data source that is present to " +
+					"satisfy the model shared with the doc resource. It may be removed in the future.",
+				Computed: true,
+			},
 			"category": schema.StringAttribute{
 				Description: "The category ID of the doc. Note that changing the category will result in a " +
 					"replacement of the doc resource.",
 				Computed: true,
 			},
 			"category_slug": schema.StringAttribute{
-				Description: "**Required**. The category ID of the doc. Note that changing the category will result " +
-					"in a replacement of the doc resource. This attribute may optionally be set in the body front matter.",
+				Description: "The slug of the doc's category. Set this to look up a doc by its `title`, or to look " +
+					"up a doc by its `id` or `path` within the category.",
 				Computed: true,
+				Optional: true,
 			},
 			"created_at": schema.StringAttribute{
 				Description: "Timestamp of when the version was created.",
@@ -259,8 +316,9 @@ func (d *docDataSource) Schema(
 				Computed: true,
 			},
 			"id": schema.StringAttribute{
-				Description: "The ID of the doc.",
+				Description: "The ID of the doc. Set this to look up the doc by its ID.",
 				Computed:    true,
+				Optional:    true,
 			},
 			"is_api": schema.BoolAttribute{
 				Computed: true,
@@ -268,6 +326,11 @@ func (d *docDataSource) Schema(
 			"is_reference": schema.BoolAttribute{
 				Computed: true,
 			},
+			"link_check": schema.StringAttribute{
+				Description: "This is an unused attribute in the data source that is present to " +
+					"satisfy the model shared with the doc resource. It may be removed in the future.",
+				Computed: true,
+			},
 			"link_external": schema.BoolAttribute{
 				Computed: true,
 			},
@@ -335,6 +398,15 @@ func (d *docDataSource) Schema(
 				Description: "If the doc has a parent doc, this is doc slug of the parent.",
 				Computed:    true,
 			},
+			"path": schema.StringAttribute{
+				Description: "The path of the doc in its category as slugs separated by `/`, such as " +
+					"`install/linux` for the `linux` doc under the `install` parent doc. Set this to look up the " +
+					"doc by its path. When `category_slug` isn't set, the path starts with the category slug, " +
+					"such as `getting-started/install/linux`. This is set when the doc is found by searching its " +
+					"category.",
+				Computed: true,
+				Optional: true,
+			},
 			"previous_slug": schema.StringAttribute{
 				Computed: true,
 			},
@@ -347,7 +419,7 @@ func (d *docDataSource) Schema(
 				Computed:    true,
 			},
 			"slug": schema.StringAttribute{
-				Description: "The slug of the doc.",
+				Description: "The slug of the doc. Set this to look up the doc by its slug.",
 				Computed:    true,
 				Optional:    true,
 			},
@@ -355,12 +427,26 @@ func (d *docDataSource) Schema(
 				Description: "The timestamp of when the doc's slug was last updated.",
 				Computed:    true,
 			},
+			"snippet_dirs": schema.ListAttribute{
+				Description: "This is an unused attribute in the data source that is present to " +
+					"satisfy the model shared with the doc resource. It may be removed in the future.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"sync_unique": schema.StringAttribute{
 				Computed: true,
 			},
-			"title": schema.StringAttribute{
-				Description: "The title of the doc.",
+			"template_vars": schema.MapAttribute{
+				Description: "This is an unused attribute in the data source that is present to " +
+					"satisfy the model shared with the doc resource. It may be removed in the future.",
 				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"title": schema.StringAttribute{
+				Description: "The title of the doc. Set this with `category_slug` to look up the doc by its " +
+					"title, which must match exactly one doc in the category.",
+				Computed: true,
+				Optional: true,
 			},
 			"type": schema.StringAttribute{
 				Description: `Type of the doc. The available types all show up under the /docs/ URL path of your ` +
@@ -0,0 +1,276 @@
+package readme
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/frontmatter"
+)
+
+// Values of the `link_check` attribute.
+const (
+	linkCheckOff   = "off"
+	linkCheckWarn  = "warn"
+	linkCheckError = "error"
+)
+
+// linkCheckDescription is the description of the `link_check` attribute.
+const linkCheckDescription = "Check links in the body when planning. Links to other docs (`doc:slug`) and " +
+	"API reference pages (`ref:slug`) must match a doc in the project or another doc in the plan, and " +
+	"images must use an absolute `http` or `https` URL. Can be `off`, `warn` to report broken links as " +
+	"warnings, or `error` to fail the plan. Defaults to `off`. Docs created in the same plan are only " +
+	"recognized when the linking doc depends on them, such as by referencing their `slug` attribute."
+
+// docLink is a link to another doc, a reference page, or an image found in a doc body.
+type docLink struct {
+	// kind is "doc", "ref", or "image".
+	kind string
+	// target is the slug of a doc or reference page, or the URL of an image.
+	target string
+	// line is the line number of the link in the body.
+	line int
+}
+
+// String returns the link as it's written in the body.
+func (l docLink) String() string {
+	if l.kind == "image" {
+		return l.target
+	}
+
+	return l.kind + ":" + l.target
+}
+
+var (
+	// docLinkRegex matches inline and reference-style links to docs and reference pages.
+	docLinkRegex = regexp.MustCompile(`(?:\]\(\s*<?|^\s*\[[^\]]+\]:\s*<?)(doc|ref):([^\s)>#?]+)`)
+	// imageRegex matches inline Markdown images and HTML image tags.
+	imageRegex = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^\s)>]+)|<img\s[^>]*src=["']([^"']+)["']`)
+	// inlineCodeRegex matches inline code spans, which aren't checked for links.
+	inlineCodeRegex = regexp.MustCompile("`[^`]*`")
+)
+
+// parseDocLinks returns the links to docs, reference pages, and images in a Markdown body.
+//
+// Links in fenced code blocks and inline code spans are ignored.
+func parseDocLinks(body string) []docLink {
+	links := []docLink{}
+	fence := ""
+
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// Skip fenced code blocks.
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+
+			continue
+		}
+
+		line = inlineCodeRegex.ReplaceAllString(line, "")
+
+		for _, match := range docLinkRegex.FindAllStringSubmatch(line, -1) {
+			links = append(links, docLink{kind: match[1], target: match[2], line: i + 1})
+		}
+
+		for _, match := range imageRegex.FindAllStringSubmatch(line, -1) {
+			target := match[1]
+			if target == "" {
+				target = match[2]
+			}
+			links = append(links, docLink{kind: "image", target: target, line: i + 1})
+		}
+	}
+
+	return links
+}
+
+// validImageURL returns true if an image URL can be loaded by ReadMe, which requires an absolute
+// HTTP or HTTPS URL.
+func validImageURL(target string) bool {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// linkTargetKey returns the lookup cache key for a link target or category in a version.
+func linkTargetKey(version, slug string) string {
+	return version + ":" + slug
+}
+
+// checkDocLinks verifies the links in a doc body and reports broken links as diagnostics with the
+// severity set by `mode`.
+//
+// Slugs of docs in the plan are registered in the lookup cache by `ModifyPlan` so links to them
+// are valid. The remaining links are verified against the docs in `categorySlug` and then
+// retrieved individually.
+func (r *docResource) checkDocLinks(
+	ctx context.Context,
+	mode, body, categorySlug string,
+	options readme.RequestOptions,
+	diags *diag.Diagnostics,
+) {
+	links := parseDocLinks(body)
+	if len(links) == 0 {
+		return
+	}
+
+	categoryKey := linkTargetKey(options.Version, categorySlug)
+	_, categoryLoaded := r.lookups.get(lookupLinkCategory, categoryKey)
+	categoryLoaded = categoryLoaded || categorySlug == ""
+	broken := []string{}
+	unchecked := []string{}
+
+	for _, link := range links {
+		if link.kind == "image" {
+			if !validImageURL(link.target) {
+				broken = append(broken, fmt.Sprintf("line %d: image %s must use an absolute http or https URL",
+					link.line, link.target))
+			}
+
+			continue
+		}
+
+		key := linkTargetKey(options.Version, link.target)
+		if _, ok := r.lookups.get(lookupLinkTarget, key); ok {
+			continue
+		}
+
+		// Register all of the docs in the category once.
+		if !categoryLoaded {
+			categoryLoaded = true
+			r.loadCategoryLinkTargets(ctx, categorySlug, options)
+
+			if _, ok := r.lookups.get(lookupLinkTarget, key); ok {
+				continue
+			}
+		}
+
+		_, apiResponse, err := r.client.Doc.Get(link.target, options)
+		if err != nil {
+			if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == http.StatusNotFound {
+				broken = append(broken, fmt.Sprintf("line %d: %s was not found", link.line, link))
+
+				continue
+			}
+
+			unchecked = append(unchecked, fmt.Sprintf("line %d: %s: %s", link.line, link, clientError(err, apiResponse)))
+
+			continue
+		}
+
+		r.lookups.set(lookupLinkTarget, key, link.target)
+	}
+
+	if len(unchecked) > 0 {
+		diags.AddAttributeWarning(
+			path.Root("body"),
+			"Unable to check links.",
+			"The following links couldn't be checked:\n"+strings.Join(unchecked, "\n"),
+		)
+	}
+
+	if len(broken) == 0 {
+		return
+	}
+
+	summary := "Broken links in doc body."
+	detail := "The following links are broken:\n" + strings.Join(broken, "\n") +
+		"\n\nSet `link_check` to `off` to disable checking links."
+
+	if mode == linkCheckError {
+		diags.AddAttributeError(path.Root("body"), summary, detail)
+
+		return
+	}
+
+	diags.AddAttributeWarning(path.Root("body"), summary, detail)
+}
+
+// loadCategoryLinkTargets registers the slugs of all docs in a category as link targets. The
+// category is only requested once while the lookup cache entry is valid.
+func (r *docResource) loadCategoryLinkTargets(
+	ctx context.Context,
+	categorySlug string,
+	options readme.RequestOptions,
+) {
+	docs, apiResponse, err := r.client.Category.GetDocs(categorySlug, options)
+	if err != nil {
+		tflog.Info(ctx, fmt.Sprintf("unable to retrieve category docs to check links: %s",
+			clientError(err, apiResponse)))
+
+		return
+	}
+
+	walkCategoryDocs(docs, func(node categoryDocNode) bool {
+		r.lookups.set(lookupLinkTarget, linkTargetKey(options.Version, node.doc.Slug), node.doc.Slug)
+
+		return true
+	})
+
+	r.lookups.set(lookupLinkCategory, linkTargetKey(options.Version, categorySlug), categorySlug)
+}
+
+// planLinks registers the planned slug of a doc as a link target and checks the links in its body
+// when `link_check` is enabled.
+//
+// Links can't be checked until the body, version, and category are known.
+func (r *docResource) planLinks(ctx context.Context, config docModel, plan *docModel, diags *diag.Diagnostics) {
+	if plan.Version.IsUnknown() {
+		return
+	}
+
+	options := readme.RequestOptions{Version: plan.Version.ValueString()}
+	if slug := plan.Slug.ValueString(); slug != "" {
+		r.lookups.set(lookupLinkTarget, linkTargetKey(options.Version, slug), slug)
+	}
+
+	mode := plan.LinkCheck.ValueString()
+	if mode == "" || mode == linkCheckOff || plan.CategorySlug.IsUnknown() {
+		return
+	}
+
+	body, known, err := frontmatter.RenderBody(ctx, config.Body.StringValue, config.BodyFile, config.TemplateVars, config.SnippetDirs)
+	if err != nil || !known {
+		return
+	}
+
+	r.checkDocLinks(ctx, mode, body, plan.CategorySlug.ValueString(), options, diags)
+}
+
+// validateLinkCheck validates the value of the `link_check` attribute.
+func validateLinkCheck(value types.String, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	switch value.ValueString() {
+	case linkCheckOff, linkCheckWarn, linkCheckError:
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("link_check"),
+		"Invalid attribute value.",
+		fmt.Sprintf("link_check must be one of '%s', '%s', or '%s', got '%s'.",
+			linkCheckOff, linkCheckWarn, linkCheckError, value.ValueString()),
+	)
+}
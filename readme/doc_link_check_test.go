@@ -0,0 +1,145 @@
+package readme
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestParseDocLinks(t *testing.T) {
+	body := strings.Join([]string{
+		"---",
+		"title: Links",
+		"---",
+		"See [the guide](doc:getting-started) and [the endpoint](ref:get-pets#responses).",
+		"Use `[code](doc:in-code)` for examples.",
+		"```markdown",
+		"[fenced](doc:in-fence) ![fenced](images/fenced.png)",
+		"```",
+		"![diagram](https://files.readme.io/diagram.png) ![local](images/local.png)",
+		`<img src="/img/logo.png" alt="logo">`,
+		"[guide]: doc:reference-style",
+		"Plain doc:not-a-link text and [external](https://example.com).",
+	}, "\n")
+
+	expect := []docLink{
+		{kind: "doc", target: "getting-started", line: 4},
+		{kind: "ref", target: "get-pets", line: 4},
+		{kind: "image", target: "https://files.readme.io/diagram.png", line: 9},
+		{kind: "image", target: "images/local.png", line: 9},
+		{kind: "image", target: "/img/logo.png", line: 10},
+		{kind: "doc", target: "reference-style", line: 11},
+	}
+
+	links := parseDocLinks(body)
+	if !reflect.DeepEqual(links, expect) {
+		t.Errorf("expected links %+v, got %+v", expect, links)
+	}
+}
+
+func TestValidImageURL(t *testing.T) {
+	tests := map[string]bool{
+		"https://files.readme.io/image.png": true,
+		"http://example.com/image.png":      true,
+		"images/image.png":                  false,
+		"/images/image.png":                 false,
+		"ftp://example.com/image.png":       false,
+		"https:///image.png":                false,
+	}
+
+	for target, expect := range tests {
+		if got := validImageURL(target); got != expect {
+			t.Errorf("expected %t for '%s', got %t", expect, target, got)
+		}
+	}
+}
+
+func TestCheckDocLinks(t *testing.T) {
+	defer gock.OffAll()
+
+	body := strings.Join([]string{
+		"[planned](doc:planned-doc)",
+		"[nested](doc:great-grandchild)",
+		"[other category](doc:elsewhere)",
+		"[reference](ref:get-pets)",
+		"[missing](doc:missing)",
+		"![local](images/local.png)",
+	}, "\n")
+
+	gock.New(testURL).
+		Get("/categories/" + mockCategory.Slug + "/docs").
+		Times(1).
+		Reply(200).
+		JSON(mockDeepCategoryDocs)
+	gock.New(testURL).Get("/docs/elsewhere").Times(1).Reply(200).JSON(mockDoc)
+	gock.New(testURL).Get("/docs/get-pets").Times(1).Reply(200).JSON(mockDoc)
+	gock.New(testURL).Get("/docs/missing").Times(2).Reply(404).JSON(map[string]string{})
+
+	client, _ := readme.NewClient(testToken, testURL)
+	r := &docResource{client: client, lookups: newLookupCache(time.Minute)}
+	r.lookups.set(lookupLinkTarget, linkTargetKey("", "planned-doc"), "planned-doc")
+
+	var diags diag.Diagnostics
+	r.checkDocLinks(context.Background(), linkCheckWarn, body, mockCategory.Slug, readme.RequestOptions{}, &diags)
+
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected one warning, got %v", diags)
+	}
+
+	detail := diags[0].Detail()
+	for _, expect := range []string{
+		"line 5: doc:missing was not found",
+		"line 6: image images/local.png must use an absolute http or https URL",
+	} {
+		if !strings.Contains(detail, expect) {
+			t.Errorf("expected the warning to contain '%s', got '%s'", expect, detail)
+		}
+	}
+	if strings.Contains(detail, "great-grandchild") || strings.Contains(detail, "get-pets") {
+		t.Errorf("expected only broken links to be reported, got '%s'", detail)
+	}
+
+	// Links verified by the first check are cached, so only the broken link is requested again.
+	diags = diag.Diagnostics{}
+	r.checkDocLinks(context.Background(), linkCheckError, body, mockCategory.Slug, readme.RequestOptions{}, &diags)
+
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected one error, got %v", diags)
+	}
+
+	if !gock.IsDone() {
+		t.Error("expected all mocked requests to be made")
+	}
+	if gock.HasUnmatchedRequest() {
+		t.Errorf("unexpected requests: %v", gock.GetUnmatchedRequests())
+	}
+}
+
+func TestValidateLinkCheck(t *testing.T) {
+	for _, value := range []types.String{
+		types.StringNull(),
+		types.StringUnknown(),
+		types.StringValue(linkCheckOff),
+		types.StringValue(linkCheckWarn),
+		types.StringValue(linkCheckError),
+	} {
+		var diags diag.Diagnostics
+		validateLinkCheck(value, &diags)
+		if diags.HasError() {
+			t.Errorf("unexpected error for %s: %v", value, diags)
+		}
+	}
+
+	var diags diag.Diagnostics
+	validateLinkCheck(types.StringValue("strict"), &diags)
+	if !diags.HasError() {
+		t.Error("expected an error for an invalid value")
+	}
+}
@@ -0,0 +1,297 @@
+package readme
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+// docLookupCandidateLimit is the most candidate docs listed when a doc lookup fails.
+const docLookupCandidateLimit = 20
+
+// docLookup is a doc found by the readme_doc data source.
+type docLookup struct {
+	slug string
+	// categorySlug is the category that was searched. It's empty when the doc is retrieved
+	// directly.
+	categorySlug string
+	// path is the doc's path in the category. It's empty when the doc is retrieved directly.
+	path string
+}
+
+// validateDocLookup checks that exactly one attribute identifies the doc and that a category is
+// set when the lookup needs one.
+func validateDocLookup(config docModel, diags *diag.Diagnostics) {
+	attributes := []struct {
+		name  string
+		value types.String
+	}{
+		{"slug", config.Slug},
+		{"id", config.ID},
+		{"title", config.Title},
+		{"path", config.Path},
+	}
+
+	set := []string{}
+	for _, attribute := range attributes {
+		if !attribute.value.IsNull() {
+			set = append(set, attribute.name)
+		}
+	}
+
+	switch len(set) {
+	case 0:
+		diags.AddError(
+			"Missing doc identifier.",
+			"One of slug, id, title, or path must be set to look up a doc.",
+		)
+
+		return
+	case 1:
+	default:
+		diags.AddError(
+			"Conflicting doc identifiers.",
+			fmt.Sprintf("Only one of slug, id, title, or path may be set to look up a doc, got %s.",
+				strings.Join(set, ", ")),
+		)
+
+		return
+	}
+
+	if !config.Title.IsNull() && config.CategorySlug.IsNull() {
+		diags.AddAttributeError(
+			path.Root("category_slug"),
+			"Missing category.",
+			"category_slug must be set to look up a doc by its title.",
+		)
+	}
+
+	if config.Path.IsNull() || config.Path.IsUnknown() {
+		return
+	}
+
+	segments, err := docPathSegments(config.Path.ValueString())
+	if err == nil && config.CategorySlug.IsNull() && len(segments) < 2 {
+		err = fmt.Errorf(
+			"the path '%s' must start with the category slug when category_slug isn't set, such as "+
+				"'category/parent/doc'", config.Path.ValueString())
+	}
+
+	if err != nil {
+		diags.AddAttributeError(path.Root("path"), "Invalid doc path.", err.Error())
+	}
+}
+
+// docPathSegments returns the slugs in a doc path. Leading and trailing slashes are ignored.
+func docPathSegments(docPath string) ([]string, error) {
+	segments := strings.Split(strings.Trim(docPath, "/"), "/")
+	for _, segment := range segments {
+		if strings.TrimSpace(segment) == "" {
+			return nil, fmt.Errorf("the path '%s' has an empty part; expected slugs separated by '/'", docPath)
+		}
+	}
+
+	return segments, nil
+}
+
+// lookupDoc returns the doc identified by the data source config.
+//
+// A doc is retrieved directly by its slug, or by its ID when no category is set. Otherwise, the
+// category's doc tree is searched for the doc's ID, title, or path. The path may start with the
+// category slug when category_slug isn't set.
+func (d *docDataSource) lookupDoc(
+	ctx context.Context,
+	config docModel,
+	options readme.RequestOptions,
+	diags *diag.Diagnostics,
+) (docLookup, bool) {
+	if !config.Slug.IsNull() {
+		return docLookup{slug: config.Slug.ValueString()}, true
+	}
+
+	categorySlug := config.CategorySlug.ValueString()
+	categoryAttribute := path.Root("category_slug")
+
+	var segments []string
+	if !config.Path.IsNull() {
+		var err error
+		segments, err = docPathSegments(config.Path.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("path"), "Invalid doc path.", err.Error())
+
+			return docLookup{}, false
+		}
+
+		if config.CategorySlug.IsNull() {
+			categorySlug, segments = segments[0], segments[1:]
+			categoryAttribute = path.Root("path")
+		}
+	}
+
+	// The category isn't needed to find a doc by its ID.
+	if !config.ID.IsNull() && categorySlug == "" {
+		slug, apiResponse, err := d.lookups.docSlug(d.client, config.ID.ValueString(), options)
+		if err != nil {
+			diags.Append(clientDiagnostic(
+				"Unable to retrieve doc metadata.",
+				err,
+				apiResponse,
+				apiErrorPaths{"DOC_NOTFOUND": path.Root("id")},
+			))
+
+			return docLookup{}, false
+		}
+
+		return docLookup{slug: slug}, true
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("searching the docs in category %s", categorySlug))
+
+	docs, apiResponse, err := d.client.Category.GetDocs(categorySlug, options)
+	if err != nil {
+		diags.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to retrieve the docs in category %s.", categorySlug),
+			err,
+			apiResponse,
+			apiErrorPaths{"CATEGORY_NOTFOUND": categoryAttribute},
+		))
+
+		return docLookup{}, false
+	}
+
+	var node categoryDocNode
+	var attribute path.Path
+
+	switch {
+	case !config.ID.IsNull():
+		node, err = findDocByID(docs, config.ID.ValueString())
+		attribute = path.Root("id")
+	case !config.Title.IsNull():
+		node, err = findDocByTitle(docs, config.Title.ValueString())
+		attribute = path.Root("title")
+	default:
+		node, err = findDocByPath(docs, segments)
+		attribute = path.Root("path")
+	}
+
+	if err != nil {
+		diags.AddAttributeError(
+			attribute,
+			"Unable to find doc.",
+			fmt.Sprintf("In category %s, %s.", categorySlug, err),
+		)
+
+		return docLookup{}, false
+	}
+
+	return docLookup{slug: node.doc.Slug, categorySlug: categorySlug, path: node.path}, true
+}
+
+// findDocByID returns the doc with the ID in a category's doc tree.
+func findDocByID(docs []readme.CategoryDocs, id string) (categoryDocNode, error) {
+	if node, ok := findCategoryDoc(docs, id, ""); ok {
+		return node, nil
+	}
+
+	return categoryDocNode{}, fmt.Errorf(
+		"no doc has the ID '%s'; the docs are %s", id, docCandidates(categoryDocNodes(docs)))
+}
+
+// findDocByTitle returns the only doc with the title in a category's doc tree.
+func findDocByTitle(docs []readme.CategoryDocs, title string) (categoryDocNode, error) {
+	nodes := categoryDocNodes(docs)
+
+	matches := []categoryDocNode{}
+	for _, node := range nodes {
+		if node.doc.Title == title {
+			matches = append(matches, node)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return categoryDocNode{}, fmt.Errorf(
+			"no doc has the title '%s'; the docs are %s", title, docCandidates(nodes))
+	case 1:
+		return matches[0], nil
+	}
+
+	return categoryDocNode{}, fmt.Errorf(
+		"the title '%s' matches more than one doc: %s. Look up the doc by its slug, id, or path instead",
+		title, docCandidates(matches))
+}
+
+// findDocByPath returns the doc at the path of slugs in a category's doc tree.
+//
+// If there's no doc at the path, the error lists the docs under the deepest parent in the path
+// that exists.
+func findDocByPath(docs []readme.CategoryDocs, segments []string) (categoryDocNode, error) {
+	nodes := categoryDocNodes(docs)
+	paths := map[string]categoryDocNode{}
+	for _, node := range nodes {
+		paths[node.path] = node
+	}
+
+	docPath := strings.Join(segments, "/")
+	if node, ok := paths[docPath]; ok {
+		return node, nil
+	}
+
+	parent := ""
+	for i := len(segments) - 1; i > 0; i-- {
+		if _, ok := paths[strings.Join(segments[:i], "/")]; ok {
+			parent = strings.Join(segments[:i], "/")
+
+			break
+		}
+	}
+
+	children := []categoryDocNode{}
+	for _, node := range nodes {
+		if docParentPath(node) == parent {
+			children = append(children, node)
+		}
+	}
+
+	under := "the top level docs are"
+	if parent != "" {
+		under = fmt.Sprintf("the docs under '%s' are", parent)
+	}
+
+	return categoryDocNode{}, fmt.Errorf("no doc has the path '%s'; %s %s", docPath, under, docCandidates(children))
+}
+
+// docParentPath returns the path of a doc's parent, which is empty for top level docs.
+func docParentPath(node categoryDocNode) string {
+	if i := strings.LastIndex(node.path, "/"); i >= 0 {
+		return node.path[:i]
+	}
+
+	return ""
+}
+
+// docCandidates lists docs by their path and title for a diagnostic.
+func docCandidates(nodes []categoryDocNode) string {
+	if len(nodes) == 0 {
+		return "(none)"
+	}
+
+	candidates := []string{}
+	for i, node := range nodes {
+		if i == docLookupCandidateLimit {
+			candidates = append(candidates, fmt.Sprintf("and %d more", len(nodes)-i))
+
+			break
+		}
+
+		candidates = append(candidates, fmt.Sprintf("%s (%q)", node.path, node.doc.Title))
+	}
+
+	return strings.Join(candidates, ", ")
+}
@@ -0,0 +1,232 @@
+package readme
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestValidateDocLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		config docModel
+		err    string
+	}{
+		{
+			name:   "slug",
+			config: docModel{Slug: types.StringValue("a-doc")},
+		},
+		{
+			name:   "id",
+			config: docModel{ID: types.StringValue("63b891d3ee384600680ce9ea")},
+		},
+		{
+			name:   "title with category",
+			config: docModel{Title: types.StringValue("A Doc"), CategorySlug: types.StringValue("guides")},
+		},
+		{
+			name:   "path with category",
+			config: docModel{Path: types.StringValue("parent/a-doc"), CategorySlug: types.StringValue("guides")},
+		},
+		{
+			name:   "path starting with the category",
+			config: docModel{Path: types.StringValue("/guides/parent/a-doc/")},
+		},
+		{
+			name:   "unknown path",
+			config: docModel{Path: types.StringUnknown()},
+		},
+		{
+			name:   "nothing",
+			config: docModel{},
+			err:    "One of slug, id, title, or path must be set",
+		},
+		{
+			name:   "more than one",
+			config: docModel{Slug: types.StringValue("a-doc"), Title: types.StringValue("A Doc")},
+			err:    "got slug, title",
+		},
+		{
+			name:   "title without category",
+			config: docModel{Title: types.StringValue("A Doc")},
+			err:    "category_slug must be set to look up a doc by its title",
+		},
+		{
+			name:   "path with only a category",
+			config: docModel{Path: types.StringValue("guides")},
+			err:    "must start with the category slug",
+		},
+		{
+			name:   "path with an empty part",
+			config: docModel{Path: types.StringValue("guides//a-doc")},
+			err:    "has an empty part",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateDocLookup(tc.config, &diags)
+
+			if tc.err == "" {
+				if diags.HasError() {
+					t.Errorf("unexpected diagnostics: %v", diags)
+				}
+
+				return
+			}
+
+			if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), tc.err) {
+				t.Errorf("expected an error containing '%s', got %v", tc.err, diags)
+			}
+		})
+	}
+}
+
+func TestFindDocByID(t *testing.T) {
+	node, err := findDocByID(mockCategoryDocs, "63b891d3ee384600680ce9ed")
+	if err != nil || node.doc.Slug != "grandchild-doc" {
+		t.Errorf("expected to find the grandchild doc, got %+v, %v", node, err)
+	}
+
+	_, err = findDocByID(mockCategoryDocs, "missing")
+	if err == nil || !strings.Contains(err.Error(), `documentation/parent-doc ("Parent Doc")`) {
+		t.Errorf("expected the error to list the docs, got %v", err)
+	}
+}
+
+func TestFindDocByTitle(t *testing.T) {
+	docs := []readme.CategoryDocs{
+		{Slug: "install", Title: "Install", Children: []readme.CategoryDocs{
+			{Slug: "install-linux", Title: "Linux"},
+			{Slug: "install-macos", Title: "macOS"},
+		}},
+		{Slug: "upgrade", Title: "Upgrade", Children: []readme.CategoryDocs{
+			{Slug: "upgrade-linux", Title: "Linux"},
+		}},
+	}
+
+	node, err := findDocByTitle(docs, "macOS")
+	if err != nil || node.doc.Slug != "install-macos" || node.path != "install/install-macos" {
+		t.Errorf("expected to find the doc by title, got %+v, %v", node, err)
+	}
+
+	_, err = findDocByTitle(docs, "Linux")
+	expect := `matches more than one doc: install/install-linux ("Linux"), upgrade/upgrade-linux ("Linux")`
+	if err == nil || !strings.Contains(err.Error(), expect) {
+		t.Errorf("expected the error to list the matching docs, got %v", err)
+	}
+
+	_, err = findDocByTitle(docs, "Windows")
+	if err == nil || !strings.Contains(err.Error(), `no doc has the title 'Windows'; the docs are install ("Install")`) {
+		t.Errorf("expected the error to list the docs, got %v", err)
+	}
+}
+
+func TestFindDocByPath(t *testing.T) {
+	node, err := findDocByPath(mockCategoryDocs, []string{"documentation", "parent-doc", "child-doc"})
+	if err != nil || node.doc.Slug != "child-doc" {
+		t.Errorf("expected to find the doc by path, got %+v, %v", node, err)
+	}
+
+	_, err = findDocByPath(mockCategoryDocs, []string{"documentation", "parent-doc", "missing", "deeper"})
+	expect := `the docs under 'documentation/parent-doc' are documentation/parent-doc/child-doc ("Child Doc")`
+	if err == nil || !strings.Contains(err.Error(), expect) {
+		t.Errorf("expected the error to list the docs under the parent, got %v", err)
+	}
+
+	_, err = findDocByPath(mockCategoryDocs, []string{"missing"})
+	if err == nil || !strings.Contains(err.Error(), "the top level docs are "+mockDoc.Slug) {
+		t.Errorf("expected the error to list the top level docs, got %v", err)
+	}
+}
+
+func TestDocCandidates(t *testing.T) {
+	if got := docCandidates(nil); got != "(none)" {
+		t.Errorf("expected no candidates, got %s", got)
+	}
+
+	nodes := []categoryDocNode{}
+	for i := 0; i < docLookupCandidateLimit+3; i++ {
+		nodes = append(nodes, categoryDocNode{path: fmt.Sprintf("doc-%d", i)})
+	}
+
+	if got := docCandidates(nodes); !strings.HasSuffix(got, ", and 3 more") {
+		t.Errorf("expected the candidates to be limited, got %s", got)
+	}
+}
+
+func TestDocDataSource_Lookup(t *testing.T) {
+	// Close all gocks when completed.
+	defer gock.OffAll()
+
+	lookupGocks := func() {
+		gock.OffAll()
+		docCommonGocks()
+		gock.New(testURL).
+			Get("/categories/" + mockCategory.Slug + "/docs").
+			Persist().
+			Reply(200).
+			JSON(mockCategoryDocs)
+		gock.New(testURL).
+			Get("/docs/" + mockDoc.Slug).
+			Persist().
+			Reply(200).
+			JSON(mockDoc)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Look up a doc by its title in a category.
+			{
+				PreConfig: lookupGocks,
+				Config: testProviderConfig + fmt.Sprintf(`
+					data "readme_doc" "test" {
+						category_slug = "%s"
+						title         = "%s"
+					}`,
+					mockCategory.Slug, mockDoc.Title,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					docResourceCommonChecks(mockDoc, "data."),
+					resource.TestCheckResourceAttr("data.readme_doc.test", "path", mockDoc.Slug),
+				),
+			},
+			// Look up a doc by a path that starts with its category.
+			{
+				PreConfig: lookupGocks,
+				Config: testProviderConfig + fmt.Sprintf(`
+					data "readme_doc" "test" {
+						path = "%s/%s"
+					}`,
+					mockCategory.Slug, mockDoc.Slug,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					docResourceCommonChecks(mockDoc, "data."),
+					resource.TestCheckResourceAttr("data.readme_doc.test", "category_slug", mockCategory.Slug),
+				),
+			},
+			// A missing doc lists the candidate docs.
+			{
+				PreConfig: lookupGocks,
+				Config: testProviderConfig + fmt.Sprintf(`
+					data "readme_doc" "test" {
+						category_slug = "%s"
+						path          = "documentation/missing"
+					}`,
+					mockCategory.Slug,
+				),
+				ExpectError: regexp.MustCompile(`the docs under 'documentation' are`),
+			},
+		},
+	})
+}
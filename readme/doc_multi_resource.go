@@ -0,0 +1,803 @@
+package readme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/markdown"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &docMultiResource{}
+	_ resource.ResourceWithConfigure      = &docMultiResource{}
+	_ resource.ResourceWithModifyPlan     = &docMultiResource{}
+	_ resource.ResourceWithValidateConfig = &docMultiResource{}
+)
+
+// docMultiResource is the resource implementation.
+type docMultiResource struct {
+	client  *readme.Client
+	config  providerConfig
+	locks   *categoryLocks
+	lookups *lookupCache
+}
+
+// docMultiModel is the resource model.
+type docMultiModel struct {
+	ID            types.String `tfsdk:"id"`
+	Body          types.String `tfsdk:"body"`
+	CategorySlug  types.String `tfsdk:"category_slug"`
+	Docs          types.Map    `tfsdk:"docs"`
+	Excerpt       types.String `tfsdk:"excerpt"`
+	Hidden        types.Bool   `tfsdk:"hidden"`
+	Order         types.Int64  `tfsdk:"order"`
+	ParentDocSlug types.String `tfsdk:"parent_doc_slug"`
+	Slug          types.String `tfsdk:"slug"`
+	Title         types.String `tfsdk:"title"`
+	Type          types.String `tfsdk:"type"`
+	Versions      types.Set    `tfsdk:"versions"`
+}
+
+// docMultiDocModel is the copy of the doc in a version.
+type docMultiDocModel struct {
+	ID            types.String `tfsdk:"id"`
+	Slug          types.String `tfsdk:"slug"`
+	CategorySlug  types.String `tfsdk:"category_slug"`
+	ParentDocSlug types.String `tfsdk:"parent_doc_slug"`
+	Created       types.Bool   `tfsdk:"created"`
+	Hash          types.String `tfsdk:"hash"`
+}
+
+// docMultiDocAttrTypes are the attribute types of a doc in the `docs` attribute.
+var docMultiDocAttrTypes = map[string]attr.Type{
+	"id":              types.StringType,
+	"slug":            types.StringType,
+	"category_slug":   types.StringType,
+	"parent_doc_slug": types.StringType,
+	"created":         types.BoolType,
+	"hash":            types.StringType,
+}
+
+// docMultiContent is the content of a doc that's compared to detect changes in a version. The
+// body is normalized so that ReadMe's formatting isn't a change.
+type docMultiContent struct {
+	Body    string `json:"body"`
+	Excerpt string `json:"excerpt"`
+	Hidden  bool   `json:"hidden"`
+	Order   int64  `json:"order"`
+	Title   string `json:"title"`
+	Type    string `json:"type"`
+}
+
+// NewDocMultiResource is a helper function to simplify the provider implementation.
+func NewDocMultiResource() resource.Resource {
+	return &docMultiResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *docMultiResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_doc_multi"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *docMultiResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	_ *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg := req.ProviderData.(*providerData)
+	r.client = cfg.client
+	r.config = cfg.config
+	r.locks = cfg.locks
+	r.lookups = cfg.lookups
+}
+
+// Schema defines the schema for the resource.
+func (r *docMultiResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manage the same doc in multiple versions on ReadMe.com\n\n" +
+			"This is useful for pages that are identical in every version, such as legal or support " +
+			"pages. A copy of the doc is kept in each version in `versions`. Adding a version creates " +
+			"the doc only in that version, and removing a version deletes only its copy.\n\n" +
+			"Each copy is compared to the configuration when the resource is refreshed, and only the " +
+			"copies that differ are updated.\n\n" +
+			"When `slug` is set and a doc with that slug already exists in a version that's added, the " +
+			"existing doc is updated and managed by this resource. This allows separate `readme_doc` " +
+			"resources to be replaced with a single `readme_doc_multi` resource. Existing docs are left " +
+			"in place when their version is removed or the resource is destroyed; only the docs this " +
+			"resource created are deleted.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The slug of the doc in the first of its versions, sorted by name.",
+				Computed:    true,
+			},
+			"body": schema.StringAttribute{
+				Description: "The body content of the doc, formatted in ReadMe or GitHub flavored Markdown.",
+				Optional:    true,
+			},
+			"category_slug": schema.StringAttribute{
+				Description: "The slug of the category the doc is in. The category must exist in each version.",
+				Required:    true,
+			},
+			"docs": schema.MapNestedAttribute{
+				Description: "The copy of the doc in each version, keyed by the version.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the doc in the version.",
+							Computed:    true,
+						},
+						"slug": schema.StringAttribute{
+							Description: "The slug of the doc in the version.",
+							Computed:    true,
+						},
+						"category_slug": schema.StringAttribute{
+							Description: "The slug of the doc's category in the version.",
+							Computed:    true,
+						},
+						"parent_doc_slug": schema.StringAttribute{
+							Description: "The slug of the doc's parent in the version. This is empty for a " +
+								"doc without a parent.",
+							Computed: true,
+						},
+						"created": schema.BoolAttribute{
+							Description: "Whether the doc was created in the version by this resource. Only " +
+								"created docs are deleted when the version is removed or the resource is destroyed.",
+							Computed: true,
+						},
+						"hash": schema.StringAttribute{
+							Description: "The SHA-256 hash of the doc's content in the version.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"excerpt": schema.StringAttribute{
+				Description: "A short summary of the doc.",
+				Optional:    true,
+			},
+			"hidden": schema.BoolAttribute{
+				Description: "Toggles if the doc is hidden or not. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"order": schema.Int64Attribute{
+				Description: "The position of the doc in the project sidebar. Defaults to `999`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(999),
+			},
+			"parent_doc_slug": schema.StringAttribute{
+				Description: "The slug of the doc's parent. The parent must exist in each version.",
+				Optional:    true,
+			},
+			"slug": schema.StringAttribute{
+				Description: "The slug of the doc in each version. When it's not set, ReadMe generates the " +
+					"slug from the title when the doc is created in a version or its title changes. The slug must " +
+					"be lowercase letters and numbers separated by hyphens, the form ReadMe saves slugs in.",
+				Optional: true,
+			},
+			"title": schema.StringAttribute{
+				Description: "The title of the doc.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: `Type of the doc. Can be "basic", "error", or "link". Defaults to "basic".`,
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("basic"),
+			},
+			"versions": schema.SetAttribute{
+				Description: "The versions to manage the doc in.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the `slug` and `versions` attributes.
+func (r *docMultiResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config docMultiModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The slug is planned for each version, so it must be saved by ReadMe as it's set.
+	if slug := config.Slug.ValueString(); slug != "" && slugify(slug) != slug {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("slug"),
+			"Invalid attribute value.",
+			fmt.Sprintf("The slug '%s' isn't in the form ReadMe saves slugs in. Use '%s' instead.", slug, slugify(slug)),
+		)
+	}
+
+	if config.Versions.IsUnknown() || config.Versions.IsNull() {
+		return
+	}
+
+	var versions []types.String
+	resp.Diagnostics.Append(config.Versions.ElementsAs(ctx, &versions, false)...)
+
+	if len(versions) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("versions"),
+			"Invalid attribute value.",
+			"versions must contain at least one version.",
+		)
+
+		return
+	}
+
+	seen := map[string]string{}
+	for _, version := range versions {
+		if version.IsUnknown() {
+			continue
+		}
+
+		clean := cleanVersion(version.ValueString())
+		if other, ok := seen[clean]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("versions"),
+				"Invalid attribute value.",
+				fmt.Sprintf("The versions '%s' and '%s' are the same version.", other, version.ValueString()),
+			)
+
+			continue
+		}
+
+		seen[clean] = version.ValueString()
+	}
+}
+
+// ModifyPlan plans the copy of the doc in each version.
+//
+// A copy keeps its state unless it differs from the configuration. A version that's added is
+// planned as a new copy.
+func (r *docMultiResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Skip when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan docMultiModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	prior := map[string]docMultiDocModel{}
+	priorTitle := types.StringNull()
+	if !req.State.Raw.IsNull() {
+		var state docMultiModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &prior, false)...)
+		priorTitle = state.Title
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	docs, ok := planDocMulti(ctx, plan, prior, priorTitle, &resp.Diagnostics)
+	if !ok {
+		plan.ID = types.StringUnknown()
+		plan.Docs = types.MapUnknown(types.ObjectType{AttrTypes: docMultiDocAttrTypes})
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+
+		return
+	}
+
+	plan.ID = docMultiID(docs)
+	plan.Docs = docMultiDocsValue(ctx, docs, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create creates the doc in each version and sets the initial Terraform state.
+func (r *docMultiResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "doc") {
+		return
+	}
+
+	var plan docMultiModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.save(ctx, plan, map[string]docMultiDocModel{}, &resp.State, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+//
+// The copies that no longer exist are removed from the state so that they're created again.
+func (r *docMultiResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state docMultiModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	docs := map[string]docMultiDocModel{}
+	resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &docs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for version, doc := range docs {
+		requestOpts := apiRequestOptions(types.StringValue(version))
+
+		response, apiResponse, err := r.client.Doc.Get(doc.Slug.ValueString(), requestOpts)
+		if err != nil {
+			if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == http.StatusNotFound {
+				tflog.Info(ctx, fmt.Sprintf("doc %s not found in version %s, removing from state", doc.Slug, version))
+				delete(docs, version)
+
+				continue
+			}
+
+			resp.Diagnostics.Append(clientDiagnostic(
+				fmt.Sprintf("Unable to retrieve the doc in version %s.", version),
+				err,
+				apiResponse,
+				apiErrorPaths{"DOC_NOTFOUND": path.Empty(), "VERSION_NOTFOUND": path.Root("versions")},
+			))
+
+			return
+		}
+
+		categorySlug, apiResponse, err := r.lookups.categorySlug(r.client, response.Category, requestOpts)
+		if err != nil {
+			resp.Diagnostics.Append(clientDiagnostic(
+				fmt.Sprintf("Unable to resolve the doc's category in version %s.", version),
+				err,
+				apiResponse,
+				nil,
+			))
+
+			return
+		}
+
+		parentSlug := ""
+		if response.ParentDoc != "" {
+			parentSlug, apiResponse, err = r.lookups.docSlug(r.client, response.ParentDoc, requestOpts)
+			if err != nil {
+				resp.Diagnostics.Append(clientDiagnostic(
+					fmt.Sprintf("Unable to resolve the doc's parent in version %s.", version),
+					err,
+					apiResponse,
+					nil,
+				))
+
+				return
+			}
+		}
+
+		docs[version] = docMultiDocModel{
+			ID:            types.StringValue(response.ID),
+			Slug:          types.StringValue(response.Slug),
+			CategorySlug:  types.StringValue(categorySlug),
+			ParentDocSlug: types.StringValue(parentSlug),
+			Created:       doc.Created,
+			Hash:          types.StringValue(newDocMultiDocContent(response).hash()),
+		}
+	}
+
+	state.Docs = docMultiDocsValue(ctx, docs, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update deletes the copies in the removed versions, saves the copies that are planned to change,
+// and sets the updated Terraform state on success.
+func (r *docMultiResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "update", "doc") {
+		return
+	}
+
+	var plan, state docMultiModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	prior := map[string]docMultiDocModel{}
+	resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.save(ctx, plan, prior, &resp.State, &resp.Diagnostics)
+}
+
+// Delete deletes the docs created in each version and removes the Terraform state on success.
+func (r *docMultiResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "delete", "doc") {
+		return
+	}
+
+	var state docMultiModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	docs := map[string]docMultiDocModel{}
+	resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &docs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.lookups.invalidate(lookupDoc)
+
+	for _, version := range sortedKeys(docs) {
+		if !r.deleteDoc(ctx, version, docs[version], &resp.Diagnostics) {
+			return
+		}
+	}
+}
+
+// save deletes the copies in the versions that were removed and creates or updates the copies
+// that are planned to change. The state is saved with the copies that exist, even if an error
+// occurs.
+func (r *docMultiResource) save(
+	ctx context.Context,
+	plan docMultiModel,
+	prior map[string]docMultiDocModel,
+	state stateSetter,
+	diags *diag.Diagnostics,
+) {
+	planned := map[string]docMultiDocModel{}
+	diags.Append(plan.Docs.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	// The saved docs begin with the prior docs since they exist until they're deleted.
+	saved := make(map[string]docMultiDocModel, len(prior))
+	for version, doc := range prior {
+		saved[version] = doc
+	}
+
+	defer func() {
+		plan.ID = docMultiID(saved)
+		plan.Docs = docMultiDocsValue(ctx, saved, diags)
+		diags.Append(state.Set(ctx, plan)...)
+	}()
+
+	defer r.lookups.invalidate(lookupDoc)
+
+	for _, version := range sortedKeys(prior) {
+		if _, ok := planned[version]; ok {
+			continue
+		}
+
+		if !r.deleteDoc(ctx, version, prior[version], diags) {
+			return
+		}
+		delete(saved, version)
+	}
+
+	params := newDocMultiParams(plan)
+
+	for _, version := range sortedKeys(planned) {
+		doc := planned[version]
+		existing, ok := prior[version]
+		if ok && docMultiDocEqual(doc, existing) {
+			continue
+		}
+
+		slug := existing.Slug.ValueString()
+		created := existing.Created
+		if !ok && plan.Slug.ValueString() != "" {
+			if slug, ok = r.existingSlug(version, plan.Slug.ValueString(), diags); !ok {
+				return
+			}
+		}
+
+		if slug == "" {
+			created = types.BoolValue(true)
+		} else if created.IsNull() || created.IsUnknown() {
+			created = types.BoolValue(false)
+		}
+
+		response, ok := r.saveDoc(ctx, version, slug, plan.Slug.ValueString(), params, diags)
+		if !ok {
+			return
+		}
+
+		saved[version] = docMultiDocModel{
+			ID:            types.StringValue(response.ID),
+			Slug:          types.StringValue(response.Slug),
+			CategorySlug:  doc.CategorySlug,
+			ParentDocSlug: doc.ParentDocSlug,
+			Created:       created,
+			Hash:          doc.Hash,
+		}
+	}
+}
+
+// saveDoc creates or updates the doc in a version while its category is locked.
+func (r *docMultiResource) saveDoc(
+	ctx context.Context,
+	version, slug, newSlug string,
+	params docWriteParams,
+	diags *diag.Diagnostics,
+) (readme.Doc, bool) {
+	unlock := r.locks.lock(ctx, categoryLockKey(version, params.CategorySlug))
+	defer unlock()
+
+	if slug == "" {
+		tflog.Info(ctx, fmt.Sprintf("creating doc %s in version %s", params.Title, version))
+	} else {
+		tflog.Info(ctx, fmt.Sprintf("updating doc %s in version %s", slug, version))
+	}
+
+	response, apiResponse, err := saveDoc(r.client, slug, newSlug, params, apiRequestOptions(types.StringValue(version)))
+	if err != nil {
+		diags.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to save the doc in version %s.", version),
+			err,
+			apiResponse,
+			apiErrorPaths{
+				"DOC_NOTFOUND":      path.Empty(),
+				"CATEGORY_NOTFOUND": path.Root("category_slug"),
+				"VERSION_NOTFOUND":  path.Root("versions"),
+			},
+		))
+
+		return readme.Doc{}, false
+	}
+
+	return response, true
+}
+
+// existingSlug returns the slug if a doc with it exists in the version, or an empty string if it
+// doesn't.
+func (r *docMultiResource) existingSlug(version, slug string, diags *diag.Diagnostics) (string, bool) {
+	_, apiResponse, err := r.client.Doc.Get(slug, apiRequestOptions(types.StringValue(version)))
+	if err != nil {
+		if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == http.StatusNotFound {
+			return "", true
+		}
+
+		diags.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to check if doc %s exists in version %s.", slug, version),
+			err,
+			apiResponse,
+			apiErrorPaths{"DOC_NOTFOUND": path.Empty(), "VERSION_NOTFOUND": path.Root("versions")},
+		))
+
+		return "", false
+	}
+
+	return slug, true
+}
+
+// deleteDoc deletes the doc in a version if the resource created it. A doc that doesn't exist is
+// ignored.
+func (r *docMultiResource) deleteDoc(
+	ctx context.Context,
+	version string,
+	doc docMultiDocModel,
+	diags *diag.Diagnostics,
+) bool {
+	if !doc.Created.ValueBool() {
+		tflog.Info(ctx, fmt.Sprintf("leaving doc %s in version %s since it existed before", doc.Slug, version))
+
+		return true
+	}
+
+	unlock := r.locks.lock(ctx, categoryLockKey(version, doc.CategorySlug.ValueString()))
+	defer unlock()
+
+	tflog.Info(ctx, fmt.Sprintf("deleting doc %s in version %s", doc.Slug, version))
+
+	_, apiResponse, err := r.client.Doc.Delete(doc.Slug.ValueString(), apiRequestOptions(types.StringValue(version)))
+	if err != nil {
+		if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == http.StatusNotFound {
+			return true
+		}
+
+		diags.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to delete the doc in version %s.", version),
+			err,
+			apiResponse,
+			apiErrorPaths{"DOC_NOTFOUND": path.Empty()},
+		))
+
+		return false
+	}
+
+	return true
+}
+
+// planDocMulti returns the planned copy of the doc in each version. It returns false if the
+// copies can't be planned because an attribute is unknown.
+//
+// `priorTitle` is the title in the state, or null if the resource is being created. ReadMe
+// generates a new slug when the title changes unless the slug is set, so it's unknown until then.
+func planDocMulti(
+	ctx context.Context,
+	plan docMultiModel,
+	prior map[string]docMultiDocModel,
+	priorTitle types.String,
+	diags *diag.Diagnostics,
+) (map[string]docMultiDocModel, bool) {
+	if plan.Versions.IsUnknown() || plan.Body.IsUnknown() || plan.CategorySlug.IsUnknown() ||
+		plan.Excerpt.IsUnknown() || plan.Hidden.IsUnknown() || plan.Order.IsUnknown() ||
+		plan.ParentDocSlug.IsUnknown() || plan.Slug.IsUnknown() || plan.Title.IsUnknown() ||
+		plan.Type.IsUnknown() {
+		return nil, false
+	}
+
+	var versions []types.String
+	diags.Append(plan.Versions.ElementsAs(ctx, &versions, false)...)
+
+	hash := types.StringValue(newDocMultiPlanContent(plan).hash())
+	parentSlug := types.StringValue(plan.ParentDocSlug.ValueString())
+
+	docs := make(map[string]docMultiDocModel, len(versions))
+	for _, version := range versions {
+		if version.IsUnknown() {
+			return nil, false
+		}
+
+		doc, ok := prior[version.ValueString()]
+		if !ok {
+			doc = docMultiDocModel{ID: types.StringUnknown(), Slug: types.StringUnknown(), Created: types.BoolUnknown()}
+		}
+
+		if plan.Slug.ValueString() != "" {
+			doc.Slug = plan.Slug
+		} else if !priorTitle.IsNull() && !priorTitle.Equal(plan.Title) {
+			doc.Slug = types.StringUnknown()
+		}
+
+		doc.CategorySlug = plan.CategorySlug
+		doc.ParentDocSlug = parentSlug
+		doc.Hash = hash
+		docs[version.ValueString()] = doc
+	}
+
+	return docs, true
+}
+
+// docMultiDocEqual returns true if the planned copy of the doc in a version is the same as its
+// state, so it doesn't need to be saved.
+func docMultiDocEqual(planned, state docMultiDocModel) bool {
+	return planned.Slug.Equal(state.Slug) &&
+		planned.CategorySlug.Equal(state.CategorySlug) &&
+		planned.ParentDocSlug.Equal(state.ParentDocSlug) &&
+		planned.Hash.Equal(state.Hash)
+}
+
+// docMultiID returns the slug of the doc in the first version, or unknown if it's not known yet.
+func docMultiID(docs map[string]docMultiDocModel) types.String {
+	versions := sortedKeys(docs)
+	if len(versions) == 0 {
+		return types.StringNull()
+	}
+
+	return docs[versions[0]].Slug
+}
+
+// newDocMultiParams returns the parameters to create or update the doc in a version.
+func newDocMultiParams(plan docMultiModel) docWriteParams {
+	// The excerpt is always sent so that removing it clears it.
+	excerpt := plan.Excerpt.ValueString()
+
+	return docWriteParams{
+		DocParams: readme.DocParams{
+			Body:          plan.Body.ValueString(),
+			CategorySlug:  plan.CategorySlug.ValueString(),
+			Hidden:        knownBoolPoint(plan.Hidden),
+			Order:         intPoint(int(plan.Order.ValueInt64())),
+			ParentDocSlug: plan.ParentDocSlug.ValueString(),
+			Title:         plan.Title.ValueString(),
+			Type:          plan.Type.ValueString(),
+		},
+		Excerpt: &excerpt,
+	}
+}
+
+// newDocMultiPlanContent returns the content of the doc in the plan.
+func newDocMultiPlanContent(plan docMultiModel) docMultiContent {
+	return docMultiContent{
+		Body:    markdown.Normalize(plan.Body.ValueString()),
+		Excerpt: plan.Excerpt.ValueString(),
+		Hidden:  plan.Hidden.ValueBool(),
+		Order:   plan.Order.ValueInt64(),
+		Title:   plan.Title.ValueString(),
+		Type:    plan.Type.ValueString(),
+	}
+}
+
+// newDocMultiDocContent returns the content of a doc retrieved from the API.
+func newDocMultiDocContent(doc readme.Doc) docMultiContent {
+	return docMultiContent{
+		Body:    markdown.Normalize(doc.Body),
+		Excerpt: doc.Excerpt,
+		Hidden:  doc.Hidden,
+		Order:   int64(doc.Order),
+		Title:   doc.Title,
+		Type:    doc.Type,
+	}
+}
+
+// hash returns the SHA-256 hash of the content.
+func (c docMultiContent) hash() string {
+	// The content is always encodable, so the error is ignored.
+	payload, _ := json.Marshal(c)
+	sum := sha256.Sum256(payload)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// docMultiDocsValue converts the copies of the doc to a map value for the `docs` attribute.
+func docMultiDocsValue(
+	ctx context.Context,
+	docs map[string]docMultiDocModel,
+	diags *diag.Diagnostics,
+) types.Map {
+	value, valueDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: docMultiDocAttrTypes}, docs)
+	diags.Append(valueDiags...)
+
+	return value
+}
+
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,272 @@
+package readme
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestDocMultiContent(t *testing.T) {
+	plan := docMultiModel{
+		Body:    types.StringValue("# Terms\n\nThe terms.  \n"),
+		Excerpt: types.StringNull(),
+		Hidden:  types.BoolValue(false),
+		Order:   types.Int64Value(3),
+		Title:   types.StringValue("Terms"),
+		Type:    types.StringValue("basic"),
+	}
+	doc := readme.Doc{Body: "# Terms\n\nThe terms.", Order: 3, Title: "Terms", Type: "basic"}
+
+	if newDocMultiPlanContent(plan).hash() != newDocMultiDocContent(doc).hash() {
+		t.Error("expected the doc to match the plan when ReadMe only normalizes the body")
+	}
+
+	doc.Title = "Changed"
+	if newDocMultiPlanContent(plan).hash() == newDocMultiDocContent(doc).hash() {
+		t.Error("expected the hash to change when the doc changes")
+	}
+}
+
+func TestPlanDocMulti(t *testing.T) {
+	ctx := context.Background()
+
+	plan := docMultiModel{
+		Body:          types.StringValue("The terms."),
+		CategorySlug:  types.StringValue("legal"),
+		Excerpt:       types.StringNull(),
+		Hidden:        types.BoolValue(false),
+		Order:         types.Int64Value(999),
+		ParentDocSlug: types.StringNull(),
+		Slug:          types.StringNull(),
+		Title:         types.StringValue("Terms"),
+		Type:          types.StringValue("basic"),
+		Versions: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("1.0"), types.StringValue("2.0"),
+		}),
+	}
+	hash := types.StringValue(newDocMultiPlanContent(plan).hash())
+
+	prior := map[string]docMultiDocModel{
+		"1.0": {
+			ID:            types.StringValue("1"),
+			Slug:          types.StringValue("terms"),
+			CategorySlug:  types.StringValue("legal"),
+			ParentDocSlug: types.StringValue(""),
+			Hash:          hash,
+		},
+		"3.0": {
+			ID:            types.StringValue("3"),
+			Slug:          types.StringValue("terms"),
+			CategorySlug:  types.StringValue("legal"),
+			ParentDocSlug: types.StringValue(""),
+			Hash:          hash,
+		},
+	}
+
+	var diags diag.Diagnostics
+	docs, ok := planDocMulti(ctx, plan, prior, plan.Title, &diags)
+	if !ok || diags.HasError() {
+		t.Fatalf("expected the docs to be planned, got %v", diags)
+	}
+
+	if len(docs) != 2 {
+		t.Fatalf("expected a doc for each version, got %v", docs)
+	}
+	if !docMultiDocEqual(docs["1.0"], prior["1.0"]) || !docs["1.0"].ID.Equal(prior["1.0"].ID) {
+		t.Errorf("expected the unchanged doc to keep its state, got %+v", docs["1.0"])
+	}
+	if !docs["2.0"].ID.IsUnknown() || !docs["2.0"].Slug.IsUnknown() || !docs["2.0"].Created.IsUnknown() ||
+		!docs["2.0"].Hash.Equal(hash) {
+		t.Errorf("expected the added version to be planned as a new doc, got %+v", docs["2.0"])
+	}
+	if id := docMultiID(docs); id.ValueString() != "terms" {
+		t.Errorf("expected the ID to be the slug in the first version, got %s", id)
+	}
+
+	// A change to the doc is planned for each version.
+	plan.Title = types.StringValue("Terms of Service")
+	docs, _ = planDocMulti(ctx, plan, prior, types.StringValue("Terms"), &diags)
+	if docMultiDocEqual(docs["1.0"], prior["1.0"]) {
+		t.Error("expected the changed doc to be updated")
+	}
+
+	// ReadMe generates a new slug from the new title when the slug isn't set.
+	if !docs["1.0"].Slug.IsUnknown() || !docs["1.0"].ID.Equal(prior["1.0"].ID) {
+		t.Errorf("expected the retitled doc's slug to be unknown, got %+v", docs["1.0"])
+	}
+	if id := docMultiID(docs); !id.IsUnknown() {
+		t.Errorf("expected the ID to be unknown until the slug is known, got %s", id)
+	}
+
+	// The slug is kept when it's set.
+	plan.Slug = types.StringValue("terms")
+	docs, _ = planDocMulti(ctx, plan, prior, types.StringValue("Terms"), &diags)
+	if !docs["1.0"].Slug.Equal(plan.Slug) {
+		t.Errorf("expected the configured slug, got %s", docs["1.0"].Slug)
+	}
+	plan.Slug = types.StringNull()
+
+	// The docs can't be planned until the versions are known.
+	plan.Versions = types.SetUnknown(types.StringType)
+	if _, ok := planDocMulti(ctx, plan, prior, plan.Title, &diags); ok {
+		t.Error("expected unknown versions not to be planned")
+	}
+}
+
+func TestDocMultiResource(t *testing.T) {
+	defer gock.OffAll()
+
+	doc := mockDoc
+	doc.Body = "The terms."
+	doc.Excerpt = ""
+	doc.Hidden = false
+	doc.Order = 999
+	doc.ParentDoc = ""
+	doc.Slug = "terms"
+	doc.Title = "Terms"
+	doc.Type = "basic"
+
+	other := doc
+	other.ID = "73b891d3ee384600680ce9ea"
+
+	config := func(versions string) string {
+		return testProviderConfig + `
+			resource "readme_doc_multi" "test" {
+				title         = "Terms"
+				body          = "The terms."
+				category_slug = "` + mockCategory.Slug + `"
+				slug          = "terms"
+				versions      = [` + versions + `]
+			}`
+	}
+
+	docGocks := func(version string, doc readme.Doc) {
+		gock.New(testURL).
+			Get("/docs/"+doc.Slug).
+			MatchHeader("x-readme-version", version).
+			Persist().
+			Reply(200).
+			JSON(doc)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The doc is created in a version and an existing doc is adopted in another.
+			{
+				PreConfig: func() {
+					gock.OffAll()
+					gock.New(testURL).
+						Get("/categories/" + mockCategory.ID).
+						Persist().
+						Reply(200).
+						JSON(mockCategory)
+					gock.New(testURL).
+						Get("/docs/"+doc.Slug).
+						MatchHeader("x-readme-version", "1.0").
+						Times(1).
+						Reply(404).
+						JSON(readme.APIErrorResponse{Error: "DOC_NOTFOUND"})
+					gock.New(testURL).
+						Post("/docs").
+						MatchHeader("x-readme-version", "1.0").
+						Times(1).
+						Reply(201).
+						JSON(doc)
+					gock.New(testURL).
+						Get("/docs/"+doc.Slug).
+						MatchHeader("x-readme-version", "2.0").
+						Times(1).
+						Reply(200).
+						JSON(other)
+					gock.New(testURL).
+						Put("/docs/"+doc.Slug).
+						MatchHeader("x-readme-version", "2.0").
+						Times(1).
+						Reply(200).
+						JSON(other)
+					docGocks("1.0", doc)
+					docGocks("2.0", other)
+				},
+				Config: config(`"1.0", "2.0"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_doc_multi.test", "id", doc.Slug),
+					resource.TestCheckResourceAttr("readme_doc_multi.test", "docs.%", "2"),
+					resource.TestCheckResourceAttr("readme_doc_multi.test", "docs.1.0.id", doc.ID),
+					resource.TestCheckResourceAttr("readme_doc_multi.test", "docs.2.0.id", other.ID),
+					resource.TestCheckResourceAttr("readme_doc_multi.test", "docs.1.0.created", "true"),
+					resource.TestCheckResourceAttr("readme_doc_multi.test", "docs.2.0.created", "false"),
+					resource.TestCheckResourceAttr(
+						"readme_doc_multi.test", "docs.2.0.category_slug", mockCategory.Slug),
+				),
+			},
+			// Removing a version leaves the doc that existed before it was adopted.
+			{
+				Config: config(`"1.0"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_doc_multi.test", "docs.%", "1"),
+					resource.TestCheckResourceAttr("readme_doc_multi.test", "docs.1.0.id", doc.ID),
+				),
+			},
+			// The created doc is deleted when the resource is destroyed.
+			{
+				PreConfig: func() {
+					gock.New(testURL).
+						Delete("/docs/"+doc.Slug).
+						MatchHeader("x-readme-version", "1.0").
+						Times(1).
+						Reply(204)
+				},
+				Config:  config(`"1.0"`),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestDocMultiResource_DuplicateVersions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig + `
+					resource "readme_doc_multi" "test" {
+						title         = "Terms"
+						category_slug = "legal"
+						versions      = ["1.0", "v1.0"]
+					}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`are the same version`),
+			},
+		},
+	})
+}
+
+func TestDocMultiResource_InvalidSlug(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig + `
+					resource "readme_doc_multi" "test" {
+						title         = "Terms"
+						category_slug = "legal"
+						slug          = "Terms of Service"
+						versions      = ["1.0"]
+					}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`terms-of-service`),
+			},
+		},
+	})
+}
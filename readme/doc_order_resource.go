@@ -0,0 +1,808 @@
+package readme
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+// Values of the `unlisted` attribute.
+const (
+	docOrderUnlistedIgnore = "ignore"
+	docOrderUnlistedAppend = "append"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &docOrderResource{}
+	_ resource.ResourceWithConfigure      = &docOrderResource{}
+	_ resource.ResourceWithModifyPlan     = &docOrderResource{}
+	_ resource.ResourceWithValidateConfig = &docOrderResource{}
+	_ resource.ResourceWithImportState    = &docOrderResource{}
+)
+
+// docOrderResource is the resource implementation.
+type docOrderResource struct {
+	client *readme.Client
+	config providerConfig
+	locks  *categoryLocks
+}
+
+// docOrderModel is the resource model.
+type docOrderModel struct {
+	ID            types.String `tfsdk:"id"`
+	CategorySlug  types.String `tfsdk:"category_slug"`
+	Orders        types.Map    `tfsdk:"orders"`
+	ParentDocSlug types.String `tfsdk:"parent_doc_slug"`
+	Slugs         types.List   `tfsdk:"slugs"`
+	Unlisted      types.String `tfsdk:"unlisted"`
+	Version       types.String `tfsdk:"version"`
+}
+
+// docOrderSibling is a doc at the level being ordered and its current order.
+type docOrderSibling struct {
+	slug  string
+	order int64
+}
+
+// NewDocOrderResource is a helper function to simplify the provider implementation.
+func NewDocOrderResource() resource.Resource {
+	return &docOrderResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *docOrderResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_doc_order"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *docOrderResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	_ *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg := req.ProviderData.(*providerData)
+	r.client = cfg.client
+	r.config = cfg.config
+	r.locks = cfg.locks
+}
+
+// Schema defines the schema for the resource.
+func (r *docOrderResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manage the order of the docs in a category or under a parent doc on ReadMe.com\n\n" +
+			"The docs are ordered as they're listed in `slugs`. Only the docs that are out of order are " +
+			"updated, and docs that are already in order keep their `order` value. Changes to the order " +
+			"made in the web UI are shown as a difference to `orders`.\n\n" +
+			"Docs that aren't listed are left alone unless `unlisted` is `append`, which moves them after " +
+			"the listed docs in their current order.\n\n" +
+			"The docs must already exist. Set `order` on `readme_doc` resources managed by this resource " +
+			"in their `lifecycle.ignore_changes` to prevent the resources from changing the order back.\n\n" +
+			"Destroying this resource doesn't change the order of the docs.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The version, category slug, and parent doc slug that are ordered, separated " +
+					"by `/`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"category_slug": schema.StringAttribute{
+				Description: "The slug of the category to order the docs in. Defaults to the provider's " +
+					"`config.default_category_slug` if set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"orders": schema.MapAttribute{
+				Description: "The `order` value of each ordered doc, keyed by slug.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"parent_doc_slug": schema.StringAttribute{
+				Description: "The slug of the parent doc to order the child docs of. The top level docs in the " +
+					"category are ordered when this isn't set.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"slugs": schema.ListAttribute{
+				Description: "The slugs of the docs in the order they appear in the sidebar.",
+				Required:    true,
+				ElementType: types.StringType,
+			},
+			"unlisted": schema.StringAttribute{
+				Description: "How to order the docs that aren't listed in `slugs`. Can be `ignore` to leave " +
+					"them alone, or `append` to move them after the listed docs. With `ignore`, the listed docs " +
+					"aren't given the `order` value of an unlisted doc. Defaults to `ignore`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(docOrderUnlistedIgnore),
+			},
+			"version": schema.StringAttribute{
+				Description: "The version of the docs. Defaults to the provider's `config.default_version` if set.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the `slugs` and `unlisted` attributes.
+func (r *docOrderResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config docOrderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Unlisted.IsNull() && !config.Unlisted.IsUnknown() {
+		switch config.Unlisted.ValueString() {
+		case docOrderUnlistedIgnore, docOrderUnlistedAppend:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("unlisted"),
+				"Invalid attribute value.",
+				fmt.Sprintf("unlisted must be one of '%s' or '%s', got '%s'.",
+					docOrderUnlistedIgnore, docOrderUnlistedAppend, config.Unlisted.ValueString()),
+			)
+		}
+	}
+
+	if config.Slugs.IsUnknown() {
+		return
+	}
+
+	var slugs []types.String
+	resp.Diagnostics.Append(config.Slugs.ElementsAs(ctx, &slugs, false)...)
+
+	seen := map[string]bool{}
+	for _, slug := range slugs {
+		if slug.IsUnknown() {
+			continue
+		}
+
+		if seen[slug.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("slugs"),
+				"Duplicate slug.",
+				fmt.Sprintf("The slug '%s' is listed more than once.", slug.ValueString()),
+			)
+		}
+		seen[slug.ValueString()] = true
+	}
+}
+
+// ModifyPlan plans the `order` values from the current order of the docs.
+func (r *docOrderResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Skip when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config docOrderModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Use the provider's default version and category slug if they're not set.
+	if config.Version.IsNull() {
+		plan.Version = types.StringNull()
+		if r.config.DefaultVersion.ValueString() != "" {
+			plan.Version = r.config.DefaultVersion
+		}
+	}
+
+	if config.CategorySlug.IsNull() {
+		if r.config.DefaultCategorySlug.ValueString() == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("category_slug"),
+				"Missing required attribute.",
+				"category_slug must be set when the provider's `config.default_category_slug` isn't set.",
+			)
+
+			return
+		}
+
+		plan.CategorySlug = r.config.DefaultCategorySlug
+	}
+
+	plan.Orders = types.MapUnknown(types.Int64Type)
+	if plan.CategorySlug.IsUnknown() || plan.ParentDocSlug.IsUnknown() || plan.Version.IsUnknown() {
+		plan.ID = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+
+		return
+	}
+
+	plan.ID = types.StringValue(docOrderID(plan))
+
+	// The orders can't be planned until all of the slugs are known.
+	slugs, known := docOrderSlugs(ctx, plan.Slugs, &resp.Diagnostics)
+	if known && !resp.Diagnostics.HasError() {
+		siblings, ok := r.siblings(plan, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+
+		orders, err := planDocOrder(siblings, slugs, plan.Unlisted.ValueString() == docOrderUnlistedAppend)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("slugs"), "Unable to order docs.", err.Error())
+
+			return
+		}
+
+		plan.Orders = docOrderOrdersValue(ctx, orders, &resp.Diagnostics)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create orders the docs and sets the initial Terraform state.
+func (r *docOrderResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "doc order") {
+		return
+	}
+
+	var plan docOrderModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, plan, &resp.State, &resp.Diagnostics)
+}
+
+// Read refreshes the `order` values of the ordered docs.
+//
+// Docs that no longer exist are left out of `orders`, which shows a difference in the plan.
+func (r *docOrderResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state docOrderModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	docs, apiResponse, err := r.client.Category.GetDocs(
+		state.CategorySlug.ValueString(),
+		apiRequestOptions(state.Version),
+	)
+	if err != nil {
+		if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("category %s not found, removing doc order from state", state.CategorySlug))
+			resp.State.RemoveResource(ctx)
+
+			return
+		}
+
+		resp.Diagnostics.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to retrieve the docs in category %s.", state.CategorySlug.ValueString()),
+			err,
+			apiResponse,
+			apiErrorPaths{"CATEGORY_NOTFOUND": path.Root("category_slug")},
+		))
+
+		return
+	}
+
+	siblings, ok := docOrderSiblings(docs, state.ParentDocSlug.ValueString())
+	if !ok {
+		tflog.Info(ctx, fmt.Sprintf("parent doc %s not found, removing doc order from state", state.ParentDocSlug))
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	// The docs are listed in the current order when they're imported.
+	if state.Slugs.IsNull() {
+		slugs := make([]string, 0, len(siblings))
+		for _, sibling := range siblings {
+			slugs = append(slugs, sibling.slug)
+		}
+
+		value, diags := types.ListValueFrom(ctx, types.StringType, slugs)
+		resp.Diagnostics.Append(diags...)
+		state.Slugs = value
+	}
+
+	slugs, _ := docOrderSlugs(ctx, state.Slugs, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Orders = docOrderOrdersValue(
+		ctx,
+		currentDocOrder(siblings, slugs, state.Unlisted.ValueString() == docOrderUnlistedAppend),
+		&resp.Diagnostics,
+	)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update orders the docs and sets the updated Terraform state on success.
+func (r *docOrderResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "update", "doc order") {
+		return
+	}
+
+	var plan docOrderModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, plan, &resp.State, &resp.Diagnostics)
+}
+
+// Delete removes the resource from the Terraform state. The order of the docs isn't changed.
+func (r *docOrderResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	tflog.Info(ctx, "removing doc order from state; the order of the docs isn't changed")
+}
+
+// ImportState imports the order of the docs in a category in the form `[version/]category_slug`,
+// or under a parent doc in the form `version/category_slug/parent_doc_slug`.
+//
+// The docs are listed in their current order.
+func (r *docOrderResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	id, err := parseImportID(req.ID, r.config.DefaultVersion.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID.", err.Error())
+
+		return
+	}
+
+	state := docOrderModel{
+		CategorySlug:  types.StringValue(id.identifier),
+		Orders:        types.MapNull(types.Int64Type),
+		ParentDocSlug: types.StringNull(),
+		Slugs:         types.ListNull(types.StringType),
+		Unlisted:      types.StringValue(docOrderUnlistedIgnore),
+		Version:       types.StringNull(),
+	}
+
+	if id.category != "" {
+		state.CategorySlug = types.StringValue(id.category)
+		state.ParentDocSlug = types.StringValue(id.identifier)
+	}
+
+	if id.version != "" {
+		state.Version = types.StringValue(id.version)
+	}
+
+	state.ID = types.StringValue(docOrderID(state))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// apply updates the `order` of the docs that are out of order and saves the state.
+//
+// The planned orders are used when they're known so the state matches the plan. Otherwise, they're
+// planned from the current order of the docs.
+func (r *docOrderResource) apply(ctx context.Context, plan docOrderModel, state stateSetter, diags *diag.Diagnostics) {
+	unlock := r.locks.lock(ctx, categoryLockKey(plan.Version.ValueString(), plan.CategorySlug.ValueString()))
+	defer unlock()
+
+	plan.ID = types.StringValue(docOrderID(plan))
+
+	siblings, ok := r.siblings(plan, diags)
+	if !ok {
+		return
+	}
+
+	orders := map[string]int64{}
+	if plan.Orders.IsUnknown() {
+		slugs, _ := docOrderSlugs(ctx, plan.Slugs, diags)
+		if diags.HasError() {
+			return
+		}
+
+		var err error
+		orders, err = planDocOrder(siblings, slugs, plan.Unlisted.ValueString() == docOrderUnlistedAppend)
+		if err != nil {
+			diags.AddAttributeError(path.Root("slugs"), "Unable to order docs.", err.Error())
+
+			return
+		}
+	} else {
+		diags.Append(plan.Orders.ElementsAs(ctx, &orders, false)...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	current := map[string]int64{}
+	for _, sibling := range siblings {
+		current[sibling.slug] = sibling.order
+	}
+
+	// Update the docs in a stable order so the requests are predictable.
+	slugs := make([]string, 0, len(orders))
+	for slug := range orders {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	requestOpts := apiRequestOptions(plan.Version)
+	for _, slug := range slugs {
+		if order, ok := current[slug]; ok && order == orders[slug] {
+			continue
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("setting the order of doc %s to %d", slug, orders[slug]))
+
+		apiResponse, err := updateDocOrder(r.client, slug, orders[slug], requestOpts)
+		if err != nil {
+			diags.Append(clientDiagnostic(
+				fmt.Sprintf("Unable to set the order of doc %s.", slug),
+				err,
+				apiResponse,
+				apiErrorPaths{"DOC_NOTFOUND": path.Root("slugs")},
+			))
+
+			return
+		}
+	}
+
+	plan.Orders = docOrderOrdersValue(ctx, orders, diags)
+	diags.Append(state.Set(ctx, plan)...)
+}
+
+// siblings returns the docs at the level being ordered, which is the top level of the category or
+// the children of the parent doc.
+func (r *docOrderResource) siblings(model docOrderModel, diags *diag.Diagnostics) ([]docOrderSibling, bool) {
+	docs, apiResponse, err := r.client.Category.GetDocs(
+		model.CategorySlug.ValueString(),
+		apiRequestOptions(model.Version),
+	)
+	if err != nil {
+		diags.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to retrieve the docs in category %s.", model.CategorySlug.ValueString()),
+			err,
+			apiResponse,
+			apiErrorPaths{"CATEGORY_NOTFOUND": path.Root("category_slug")},
+		))
+
+		return nil, false
+	}
+
+	siblings, ok := docOrderSiblings(docs, model.ParentDocSlug.ValueString())
+	if !ok {
+		diags.AddAttributeError(
+			path.Root("parent_doc_slug"),
+			"Parent doc not found.",
+			fmt.Sprintf("The doc '%s' wasn't found in the category '%s'.",
+				model.ParentDocSlug.ValueString(), model.CategorySlug.ValueString()),
+		)
+
+		return nil, false
+	}
+
+	return siblings, true
+}
+
+// docOrderSiblings returns the top level docs in a category, or the children of the parent doc if
+// `parent` is set. False is returned if the parent doc isn't found.
+func docOrderSiblings(docs []readme.CategoryDocs, parent string) ([]docOrderSibling, bool) {
+	if parent != "" {
+		node, ok := findCategoryDoc(docs, "", parent)
+		if !ok {
+			return nil, false
+		}
+
+		docs = node.doc.Children
+	}
+
+	siblings := make([]docOrderSibling, 0, len(docs))
+	for _, doc := range docs {
+		siblings = append(siblings, docOrderSibling{slug: doc.Slug, order: int64(doc.Order)})
+	}
+
+	return siblings, true
+}
+
+// updateDocOrder sets the `order` of a doc.
+//
+// The API client's `Doc.Update` sends every field of the doc, which would reset the fields that
+// aren't set, so the request is made directly with only the order.
+func updateDocOrder(
+	client *readme.Client,
+	slug string,
+	order int64,
+	options readme.RequestOptions,
+) (*readme.APIResponse, error) {
+	payload, err := json.Marshal(map[string]int64{"order": order})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal request: %w", err)
+	}
+
+	return client.APIRequest(&readme.APIRequest{
+		Endpoint:       fmt.Sprintf("%s/%s", readme.DocEndpoint, slug),
+		Headers:        []readme.RequestHeader{{"Content-Type": "application/json"}},
+		Method:         "PUT",
+		OkStatusCode:   []int{http.StatusOK},
+		Payload:        payload,
+		RequestOptions: options,
+		Response:       &readme.Doc{},
+		UseAuth:        true,
+	})
+}
+
+// planDocOrder returns the `order` value of each doc so the listed docs are in the order of
+// `slugs`, followed by the unlisted docs when `appendUnlisted` is true.
+//
+// The fewest docs are changed: the most docs that can keep their value do, and the other docs
+// are given the next value after the doc before them. The values of the unlisted docs are skipped
+// when they're ignored so the docs don't share a value with them. An error is returned if a slug
+// isn't one of the siblings.
+func planDocOrder(siblings []docOrderSibling, slugs []string, appendUnlisted bool) (map[string]int64, error) {
+	sequence, err := docOrderSequence(siblings, slugs, appendUnlisted)
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := map[int64]bool{}
+	if !appendUnlisted {
+		listed := map[string]bool{}
+		for _, slug := range slugs {
+			listed[slug] = true
+		}
+
+		for _, sibling := range siblings {
+			if !listed[sibling.slug] {
+				reserved[sibling.order] = true
+			}
+		}
+	}
+
+	keep := keptDocOrder(sequence, reserved)
+	orders := make(map[string]int64, len(sequence))
+
+	prev := int64(-1)
+	for i, doc := range sequence {
+		if keep[i] {
+			prev = doc.order
+		} else {
+			prev++
+			for reserved[prev] {
+				prev++
+			}
+		}
+
+		orders[doc.slug] = prev
+	}
+
+	return orders, nil
+}
+
+// currentDocOrder returns the current `order` value of the docs that are ordered. Listed docs
+// that no longer exist are left out.
+func currentDocOrder(siblings []docOrderSibling, slugs []string, appendUnlisted bool) map[string]int64 {
+	current := map[string]int64{}
+	for _, sibling := range siblings {
+		current[sibling.slug] = sibling.order
+	}
+
+	orders := map[string]int64{}
+	for _, slug := range slugs {
+		if order, ok := current[slug]; ok {
+			orders[slug] = order
+		}
+	}
+
+	if appendUnlisted {
+		for slug, order := range current {
+			orders[slug] = order
+		}
+	}
+
+	return orders
+}
+
+// docOrderSequence returns the docs in the order they should appear with their current order.
+func docOrderSequence(siblings []docOrderSibling, slugs []string, appendUnlisted bool) ([]docOrderSibling, error) {
+	current := map[string]docOrderSibling{}
+	for _, sibling := range siblings {
+		current[sibling.slug] = sibling
+	}
+
+	sequence := make([]docOrderSibling, 0, len(siblings))
+	listed := map[string]bool{}
+	missing := []string{}
+
+	for _, slug := range slugs {
+		sibling, ok := current[slug]
+		if !ok {
+			missing = append(missing, slug)
+
+			continue
+		}
+
+		sequence = append(sequence, sibling)
+		listed[slug] = true
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the docs %s weren't found at the level being ordered; docs must exist "+
+			"and share the same category and parent doc", strings.Join(missing, ", "))
+	}
+
+	if appendUnlisted {
+		// The siblings are returned by the API in their current order.
+		for _, sibling := range siblings {
+			if !listed[sibling.slug] {
+				sequence = append(sequence, sibling)
+			}
+		}
+	}
+
+	return sequence, nil
+}
+
+// keptDocOrder returns which docs keep their `order` value. A doc can keep its value when
+// there's room for the docs before it between it and the previous doc that keeps its value, so
+// the kept docs are the longest subsequence where `order` minus the position doesn't decrease
+// and isn't negative.
+//
+// The `reserved` values can't be used, so `order` is counted in the values that are free and a
+// doc with a reserved value can't keep it.
+func keptDocOrder(sequence []docOrderSibling, reserved map[int64]bool) []bool {
+	taken := make([]int64, 0, len(reserved))
+	for order := range reserved {
+		taken = append(taken, order)
+	}
+	sort.Slice(taken, func(i, j int) bool { return taken[i] < taken[j] })
+
+	// free returns the number of free values below a doc's value, or -1 if its value is reserved.
+	free := func(order int64) int64 {
+		if reserved[order] {
+			return -1
+		}
+
+		below := sort.Search(len(taken), func(j int) bool { return taken[j] >= order })
+
+		return order - int64(below)
+	}
+
+	// tails[i] is the index of the smallest last key of a subsequence of length i+1.
+	tails := []int{}
+	previous := make([]int, len(sequence))
+	key := func(i int) int64 { return free(sequence[i].order) - int64(i) }
+
+	for i := range sequence {
+		previous[i] = -1
+		if key(i) < 0 {
+			continue
+		}
+
+		length := sort.Search(len(tails), func(j int) bool {
+			return key(tails[j]) > key(i)
+		})
+
+		if length > 0 {
+			previous[i] = tails[length-1]
+		}
+
+		if length == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[length] = i
+		}
+	}
+
+	keep := make([]bool, len(sequence))
+	if len(tails) == 0 {
+		return keep
+	}
+
+	for i := tails[len(tails)-1]; i >= 0; i = previous[i] {
+		keep[i] = true
+	}
+
+	return keep
+}
+
+// docOrderSlugs returns the listed slugs and whether they're all known.
+func docOrderSlugs(ctx context.Context, value types.List, diags *diag.Diagnostics) ([]string, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, false
+	}
+
+	var elements []types.String
+	diags.Append(value.ElementsAs(ctx, &elements, false)...)
+
+	slugs := make([]string, 0, len(elements))
+	for _, element := range elements {
+		if element.IsUnknown() {
+			return nil, false
+		}
+
+		slugs = append(slugs, element.ValueString())
+	}
+
+	return slugs, true
+}
+
+// docOrderOrdersValue returns the `orders` attribute value.
+func docOrderOrdersValue(ctx context.Context, orders map[string]int64, diags *diag.Diagnostics) types.Map {
+	value, d := types.MapValueFrom(ctx, types.Int64Type, orders)
+	diags.Append(d...)
+
+	return value
+}
+
+// docOrderID returns the ID of the resource from the version, category slug, and parent doc slug.
+func docOrderID(model docOrderModel) string {
+	parts := []string{}
+	for _, part := range []types.String{model.Version, model.CategorySlug, model.ParentDocSlug} {
+		if part.ValueString() != "" {
+			parts = append(parts, part.ValueString())
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,239 @@
+package readme
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// docOrderTestSiblings returns siblings with the slugs and orders.
+func docOrderTestSiblings(pairs ...any) []docOrderSibling {
+	siblings := []docOrderSibling{}
+	for i := 0; i < len(pairs); i += 2 {
+		siblings = append(siblings, docOrderSibling{slug: pairs[i].(string), order: int64(pairs[i+1].(int))})
+	}
+
+	return siblings
+}
+
+func TestPlanDocOrder(t *testing.T) {
+	tests := []struct {
+		name           string
+		siblings       []docOrderSibling
+		slugs          []string
+		appendUnlisted bool
+		expect         map[string]int64
+		err            string
+	}{
+		{
+			name:     "already in order",
+			siblings: docOrderTestSiblings("a", 10, "b", 20, "c", 30),
+			slugs:    []string{"a", "b", "c"},
+			expect:   map[string]int64{"a": 10, "b": 20, "c": 30},
+		},
+		{
+			name:     "move to the front with room",
+			siblings: docOrderTestSiblings("a", 10, "b", 20, "c", 30),
+			slugs:    []string{"c", "a", "b"},
+			expect:   map[string]int64{"c": 0, "a": 10, "b": 20},
+		},
+		{
+			name:     "move between docs with room",
+			siblings: docOrderTestSiblings("a", 10, "b", 20, "c", 30),
+			slugs:    []string{"a", "c", "b"},
+			expect:   map[string]int64{"a": 10, "c": 11, "b": 20},
+		},
+		{
+			name:     "move to the end",
+			siblings: docOrderTestSiblings("a", 0, "b", 1, "c", 2),
+			slugs:    []string{"b", "c", "a"},
+			expect:   map[string]int64{"b": 1, "c": 2, "a": 3},
+		},
+		{
+			name:     "no room before a doc",
+			siblings: docOrderTestSiblings("a", 0, "b", 1, "c", 2),
+			slugs:    []string{"c", "a", "b"},
+			expect:   map[string]int64{"c": 2, "a": 3, "b": 4},
+		},
+		{
+			name:     "same orders",
+			siblings: docOrderTestSiblings("a", 999, "b", 999, "c", 999),
+			slugs:    []string{"a", "b", "c"},
+			expect:   map[string]int64{"a": 0, "b": 1, "c": 999},
+		},
+		{
+			name:     "unlisted docs are ignored",
+			siblings: docOrderTestSiblings("a", 0, "x", 1, "b", 2),
+			slugs:    []string{"b", "a"},
+			expect:   map[string]int64{"b": 2, "a": 3},
+		},
+		{
+			name:     "unlisted doc values are skipped",
+			siblings: docOrderTestSiblings("a", 0, "b", 1, "x", 2),
+			slugs:    []string{"b", "a"},
+			expect:   map[string]int64{"b": 1, "a": 3},
+		},
+		{
+			name:     "unlisted doc values don't count as room",
+			siblings: docOrderTestSiblings("a", 0, "x", 1, "c", 3, "b", 10),
+			slugs:    []string{"a", "b", "c"},
+			expect:   map[string]int64{"a": 0, "b": 2, "c": 3},
+		},
+		{
+			name:           "unlisted docs are appended",
+			siblings:       docOrderTestSiblings("x", 0, "a", 1, "y", 2, "b", 3),
+			slugs:          []string{"a", "b"},
+			appendUnlisted: true,
+			expect:         map[string]int64{"a": 1, "b": 3, "x": 4, "y": 5},
+		},
+		{
+			name:     "missing doc",
+			siblings: docOrderTestSiblings("a", 0),
+			slugs:    []string{"a", "missing"},
+			err:      "the docs missing weren't found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			orders, err := planDocOrder(tc.siblings, tc.slugs, tc.appendUnlisted)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected an error containing '%s', got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(orders, tc.expect) {
+				t.Errorf("expected %v, got %v", tc.expect, orders)
+			}
+		})
+	}
+}
+
+func TestCurrentDocOrder(t *testing.T) {
+	siblings := docOrderTestSiblings("a", 0, "x", 1, "b", 2)
+
+	orders := currentDocOrder(siblings, []string{"b", "a", "deleted"}, false)
+	if expect := map[string]int64{"a": 0, "b": 2}; !reflect.DeepEqual(orders, expect) {
+		t.Errorf("expected %v, got %v", expect, orders)
+	}
+
+	orders = currentDocOrder(siblings, []string{"b", "a"}, true)
+	if expect := map[string]int64{"a": 0, "b": 2, "x": 1}; !reflect.DeepEqual(orders, expect) {
+		t.Errorf("expected %v, got %v", expect, orders)
+	}
+}
+
+func TestDocOrderSiblings(t *testing.T) {
+	siblings, ok := docOrderSiblings(mockDeepCategoryDocs, "")
+	if !ok || len(siblings) != 2 || siblings[0].slug != "top" || siblings[1].slug != "other" {
+		t.Errorf("expected the top level docs, got %v", siblings)
+	}
+
+	siblings, ok = docOrderSiblings(mockDeepCategoryDocs, "top")
+	if !ok || len(siblings) != 2 || siblings[0].slug != "first-child" || siblings[1].slug != "second-child" {
+		t.Errorf("expected the children of the parent doc, got %v", siblings)
+	}
+
+	if _, ok := docOrderSiblings(mockDeepCategoryDocs, "missing"); ok {
+		t.Error("expected a missing parent doc not to be found")
+	}
+}
+
+func TestDocOrderResource(t *testing.T) {
+	defer gock.OffAll()
+
+	before := []readme.CategoryDocs{
+		{ID: "1", Slug: "first", Order: 10},
+		{ID: "2", Slug: "second", Order: 20},
+		{ID: "3", Slug: "third", Order: 30},
+	}
+	after := []readme.CategoryDocs{
+		{ID: "3", Slug: "third", Order: 0},
+		{ID: "1", Slug: "first", Order: 10},
+		{ID: "2", Slug: "second", Order: 20},
+	}
+
+	// The docs are returned in the new order once the doc is moved.
+	moved := false
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Only the doc that's out of order is updated.
+			{
+				PreConfig: func() {
+					gock.OffAll()
+					gock.New(testURL).
+						Get("/categories/" + mockCategory.Slug + "/docs").
+						AddMatcher(func(*http.Request, *gock.Request) (bool, error) { return !moved, nil }).
+						Persist().
+						Reply(200).
+						JSON(before)
+					gock.New(testURL).
+						Get("/categories/" + mockCategory.Slug + "/docs").
+						AddMatcher(func(*http.Request, *gock.Request) (bool, error) { return moved, nil }).
+						Persist().
+						Reply(200).
+						JSON(after)
+					gock.New(testURL).
+						Put("/docs/third").
+						BodyString(`{"order":0}`).
+						AddMatcher(func(*http.Request, *gock.Request) (bool, error) {
+							moved = true
+
+							return true, nil
+						}).
+						Times(1).
+						Reply(200).
+						JSON(mockDoc)
+				},
+				Config: testProviderConfig + `
+					resource "readme_doc_order" "test" {
+						category_slug = "` + mockCategory.Slug + `"
+						slugs         = ["third", "first", "second"]
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_doc_order.test", "id", mockCategory.Slug),
+					resource.TestCheckResourceAttr("readme_doc_order.test", "unlisted", "ignore"),
+					resource.TestCheckResourceAttr("readme_doc_order.test", "orders.third", "0"),
+					resource.TestCheckResourceAttr("readme_doc_order.test", "orders.first", "10"),
+					resource.TestCheckResourceAttr("readme_doc_order.test", "orders.second", "20"),
+				),
+			},
+			// A doc that isn't in the category is an error.
+			{
+				Config: testProviderConfig + `
+					resource "readme_doc_order" "test" {
+						category_slug = "` + mockCategory.Slug + `"
+						slugs         = ["third", "missing"]
+					}`,
+				ExpectError: regexp.MustCompile(`the docs missing weren't found`),
+			},
+			// Import the order of the docs.
+			{
+				Config: testProviderConfig + `
+					resource "readme_doc_order" "test" {
+						category_slug = "` + mockCategory.Slug + `"
+						slugs         = ["third", "first", "second"]
+					}`,
+				ResourceName:      "readme_doc_order.test",
+				ImportState:       true,
+				ImportStateId:     mockCategory.Slug,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -2,25 +2,30 @@ package readme
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/liveoaklabs/readme-api-go-client/readme"
 	"github.com/liveoaklabs/terraform-provider-readme/readme/frontmatter"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/markdown"
 )
 
 const docResourceDesc = `
@@ -33,6 +38,10 @@ See <https://docs.readme.com/main/reference/getdoc> for more information about t
 Docs on ReadMe support setting some attributes using front matter.
 Resource attributes take precedence over front matter attributes in the provider.
 
+The ` + "`deprecated`, `excerpt`, `icon`, `link_external`, `link_url`, and `metadata`" + ` attributes
+are cleared on the doc when they're removed from both the configuration and the front matter. If
+they were never set, the values set on ReadMe are kept.
+
 Refer to <https://docs.readme.com/main/docs/rdme> for more information about using front matter
 in ReadMe docs and custom pages.
 
@@ -47,7 +56,8 @@ This creates challenges when managing docs with Terraform. To address this, the
 the ` + "`use_slug`" + ` attribute. When set, the provider will attempt to manage an existing
 doc by its slug. This can also be set in front matter using the ` + "`slug`" + ` key.
 
-If this attribute is set and the doc does not exist, an error will be returned. This is intended
+If this attribute is set and the doc does not exist, an error will be returned, unless it's set
+from the ` + "`slug`" + ` front matter key, in which case the doc is created with that slug. This is intended
 to be set when inheriting management of an existing doc or when customizing the slug *after*
 the doc has been created.
 
@@ -68,11 +78,12 @@ attribute to manage an API reference's parent doc.
 
 When destroying a doc, the provider will check for child docs and prevent deletion if they exist.
 This behavior can be controlled with the ` + "`config.destroy_child_docs`" + ` attribute. When set to
-true, the provider will destroy child docs prior to deleting the parent doc. Setting this as a provider
-configuration attribute allows for it to be toggled without requiring changes to the resource.
+true, the provider will destroy child docs at any depth prior to deleting the parent doc, starting with
+the most deeply nested docs. Setting this as a provider configuration attribute allows for it to be
+toggled without requiring changes to the resource.
 
 When ` + "`config.destroy_child_docs`" + ` is set to ` + "`true`" + `, the provider will log a
-warning when child docs are deleted before the parent doc.
+warning listing each child doc that was deleted before the parent doc.
 
 For best results, manage docs with Terraform and set their relationship by referencing the resource
 address of the parent doc in the child doc's ` + "`parent_doc_slug`" + ` or ` + "`depends_on`" + `
@@ -88,8 +99,10 @@ var (
 
 // docResource is the data source implementation.
 type docResource struct {
-	client *readme.Client
-	config providerConfig
+	client  *readme.Client
+	config  providerConfig
+	locks   *categoryLocks
+	lookups *lookupCache
 }
 
 // NewDocResource is a helper function to simplify the provider implementation.
@@ -119,6 +132,8 @@ func (r *docResource) Configure(
 	cfg := req.ProviderData.(*providerData)
 	r.client = cfg.client
 	r.config = cfg.config
+	r.locks = cfg.locks
+	r.lookups = cfg.lookups
 }
 
 // ValidateConfig is used for validating attribute values.
@@ -130,10 +145,30 @@ func (r docResource) ValidateConfig(
 	var data docModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
+	validateBodyConfig(data.Body.StringValue, data.BodyFile, false, &resp.Diagnostics)
+	validateLinkCheck(data.LinkCheck, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || data.BodyFile.IsUnknown() {
+		return
+	}
+
+	body, known, err := frontmatter.RenderBody(ctx, data.Body.StringValue, data.BodyFile, data.TemplateVars, data.SnippetDirs)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Unable to read body.", err.Error())
+
+		return
+	}
+
+	// The front matter can't be checked until the body can be rendered.
+	if !known {
+		return
+	}
+
 	// category or category_slug must be set. If the attributes aren't set, check the body front matter.
-	if data.Category.IsNull() && data.CategorySlug.IsNull() {
+	// The provider's default category slug is used when neither is set, but it's only known once the
+	// provider is configured.
+	if data.Category.IsNull() && data.CategorySlug.IsNull() && !r.hasDefaultCategory() {
 		// check front matter for 'category'.
-		categoryMatter, diag := frontmatter.GetValue(ctx, data.Body.ValueString(), "Category")
+		categoryMatter, diag := frontmatter.GetValue(ctx, body, "Category")
 		if diag != "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("category"),
@@ -145,11 +180,7 @@ func (r docResource) ValidateConfig(
 		}
 
 		// check front matter for 'category_slug'.
-		categorySlugMatter, diag := frontmatter.GetValue(
-			ctx,
-			data.Body.ValueString(),
-			"CategorySlug",
-		)
+		categorySlugMatter, diag := frontmatter.GetValue(ctx, body, "CategorySlug")
 		if diag != "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("category_slug"),
@@ -165,7 +196,8 @@ func (r docResource) ValidateConfig(
 			resp.Diagnostics.AddAttributeError(
 				path.Root("category"),
 				"Missing required attribute.",
-				"category or category_slug must be set. These can be set using the attribute or in the body front matter.",
+				"category or category_slug must be set. These can be set using the attribute, in the body "+
+					"front matter, or with the provider's `config.default_category_slug` attribute.",
 			)
 
 			return
@@ -188,10 +220,53 @@ func (r *docResource) ModifyPlan(
 		return
 	}
 
+	// Use the provider's default version and category slug if they're not set.
+	var config docModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Version.IsNull() && r.config.DefaultVersion.ValueString() != "" {
+		plan.Version = r.config.DefaultVersion
+	}
+
+	if config.Category.IsNull() && config.CategorySlug.IsNull() &&
+		plan.Category.ValueString() == "" && plan.CategorySlug.ValueString() == "" &&
+		r.config.DefaultCategorySlug.ValueString() != "" {
+		plan.CategorySlug = r.config.DefaultCategorySlug
+	}
+
+	// The API may generate a new slug when the title changes unless the slug is set.
+	if state != nil && config.Slug.IsNull() && !state.Title.Equal(plan.Title) && !r.slugMatter(ctx, config) {
+		plan.Slug = types.StringUnknown()
+	}
+
+	// The 'error' and 'metadata' objects may be set in the body front matter.
+	docFrontMatterObjects(ctx, config, plan)
+
+	// Only the hash of a body file is kept in the state.
+	plan.BodyHash = planBodyHash(plan.BodyFile, &resp.Diagnostics)
+	if !plan.BodyFile.IsNull() {
+		plan.Body = markdown.NewNullValue()
+		plan.BodyClean = types.StringNull()
+		plan.BodyHTML = types.StringNull()
+	}
+
+	// The body is rendered from its template when templating is enabled.
+	plan.BodyRendered = planBodyRendered(
+		ctx, config.Body.StringValue, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs, &resp.Diagnostics,
+	)
+
+	// Links in the body are checked against the project and the other docs in the plan.
+	r.planLinks(ctx, config, plan, &resp.Diagnostics)
+
 	if state == nil {
 		tflog.Info(ctx, fmt.Sprintf("state is nil for doc %s", plan.Slug.ValueString()))
-		plan.BodyClean = types.StringUnknown()
-		plan.BodyHTML = types.StringUnknown()
+		if plan.BodyFile.IsNull() {
+			plan.BodyClean = types.StringUnknown()
+			plan.BodyHTML = types.StringUnknown()
+		}
 		plan.Revision = types.Int64Unknown()
 		plan.UpdatedAt = types.StringUnknown()
 		plan.User = types.StringUnknown()
@@ -201,7 +276,18 @@ func (r *docResource) ModifyPlan(
 		return
 	}
 
-	diags := resp.Plan.Set(ctx, plan)
+	// Optional attributes removed from the configuration and the front matter are cleared.
+	previous := map[string]bool{}
+	configured, diags := req.Private.GetKey(ctx, docConfiguredKey)
+	resp.Diagnostics.Append(diags...)
+	if len(configured) > 0 {
+		if err := json.Unmarshal(configured, &previous); err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("unable to read the configured attributes: %s", err))
+		}
+	}
+	docPlanRemoved(ctx, config, plan, previous)
+
+	diags = resp.Plan.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 
 	// The 'algolia', 'revision', 'updated_at', and 'user' attributes are
@@ -209,10 +295,19 @@ func (r *docResource) ModifyPlan(
 	// attributes are changed, set these attributes to unknown to trigger a
 	// refresh.
 	if !state.BodyClean.Equal(plan.BodyClean) ||
+		!state.BodyHash.Equal(plan.BodyHash) ||
 		!state.BodyHTML.Equal(plan.BodyHTML) ||
+		!state.BodyRendered.Equal(plan.BodyRendered) ||
 		!state.Category.Equal(plan.Category) ||
 		!state.CategorySlug.Equal(plan.CategorySlug) ||
+		!state.Deprecated.Equal(plan.Deprecated) ||
+		!state.Error.Equal(plan.Error) ||
+		!state.Excerpt.Equal(plan.Excerpt) ||
 		!state.Hidden.Equal(plan.Hidden) ||
+		!state.Icon.Equal(plan.Icon) ||
+		!state.LinkExternal.Equal(plan.LinkExternal) ||
+		!state.LinkURL.Equal(plan.LinkURL) ||
+		!state.Metadata.Equal(plan.Metadata) ||
 		!state.Order.Equal(plan.Order) ||
 		!state.ParentDoc.Equal(plan.ParentDoc) ||
 		!state.ParentDocSlug.Equal(plan.ParentDocSlug) ||
@@ -240,14 +335,194 @@ func (r *docResource) ModifyPlan(
 	resp.Diagnostics.Append(diags...)
 }
 
-// docPlanToParams maps plan attributes to a `readme.DocParams` struct to create or update a doc.
-func docPlanToParams(ctx context.Context, plan docModel) readme.DocParams {
-	params := readme.DocParams{
-		Body:   plan.Body.ValueString(),
-		Hidden: plan.Hidden.ValueBoolPointer(),
-		Order:  intPoint(int(plan.Order.ValueInt64())),
-		Title:  plan.Title.ValueString(),
-		Type:   plan.Type.ValueString(),
+// docFrontMatterObjects sets the planned 'error' and 'metadata' objects from the body front matter
+// when they aren't configured.
+//
+// Errors reading the body are reported by the attribute plan modifiers, so they're ignored here.
+func docFrontMatterObjects(ctx context.Context, config docModel, plan *docModel) {
+	if !config.Error.IsNull() && !config.Metadata.IsNull() {
+		return
+	}
+
+	body, _, err := frontmatter.RenderBody(ctx, config.Body.StringValue, config.BodyFile, config.TemplateVars, config.SnippetDirs)
+	if err != nil {
+		return
+	}
+
+	if config.Error.IsNull() {
+		if value, _ := frontmatter.GetValue(ctx, body, "Error"); value != (reflect.Value{}) {
+			plan.Error = docModelErrorValue(value.Interface().(readme.DocErrorObject))
+		}
+	}
+
+	if config.Metadata.IsNull() {
+		if value, _ := frontmatter.GetValue(ctx, body, "Metadata"); value != (reflect.Value{}) {
+			metadata := value.Interface().(frontmatter.Metadata)
+
+			images := make([]any, 0, len(metadata.Image))
+			for _, image := range metadata.Image {
+				images = append(images, image)
+			}
+
+			plan.Metadata = docModelMetadataValue(readme.DocMetadata{
+				Description: metadata.Description,
+				Image:       images,
+				Title:       metadata.Title,
+			})
+		}
+	}
+}
+
+// docConfiguredKey is the private state key of the optional attributes that were set in the
+// configuration or the body front matter when the doc was last saved.
+const docConfiguredKey = "configured"
+
+// docConfigured returns the optional attributes that are set in the configuration or the body
+// front matter. False is returned if the body isn't known yet.
+func docConfigured(ctx context.Context, config docModel) (map[string]bool, bool) {
+	if config.Body.IsUnknown() || config.BodyFile.IsUnknown() {
+		return nil, false
+	}
+
+	body, _, err := frontmatter.RenderBody(ctx, config.Body.StringValue, config.BodyFile, config.TemplateVars, config.SnippetDirs)
+	if err != nil {
+		return nil, false
+	}
+
+	matter := func(attribute string) bool {
+		value, _ := frontmatter.GetValue(ctx, body, attribute)
+
+		return value != (reflect.Value{})
+	}
+
+	return map[string]bool{
+		"deprecated":    !config.Deprecated.IsNull() || matter("Deprecated"),
+		"excerpt":       !config.Excerpt.IsNull() || matter("Excerpt"),
+		"icon":          !config.Icon.IsNull() || matter("Icon"),
+		"link_external": !config.LinkExternal.IsNull() || matter("LinkExternal"),
+		"link_url":      !config.LinkURL.IsNull() || matter("LinkURL"),
+		"metadata":      !config.Metadata.IsNull() || matter("Metadata"),
+	}, true
+}
+
+// docPlanRemoved plans the empty value of the optional attributes that were set when the doc was
+// last saved but are no longer set in the configuration or the body front matter. Otherwise, their
+// values would be kept from the state and removing one would never clear it.
+//
+// Attributes that were never set are left alone so values set on ReadMe are kept.
+func docPlanRemoved(ctx context.Context, config docModel, plan *docModel, previous map[string]bool) {
+	current, ok := docConfigured(ctx, config)
+	if !ok {
+		return
+	}
+
+	removed := func(attribute string) bool {
+		return previous[attribute] && !current[attribute]
+	}
+
+	if removed("deprecated") {
+		plan.Deprecated = types.BoolValue(false)
+	}
+
+	if removed("excerpt") {
+		plan.Excerpt = types.StringValue("")
+	}
+
+	if removed("icon") {
+		plan.Icon = types.StringValue("")
+	}
+
+	if removed("link_external") {
+		plan.LinkExternal = types.BoolValue(false)
+	}
+
+	if removed("link_url") {
+		plan.LinkURL = types.StringValue("")
+	}
+
+	if removed("metadata") {
+		plan.Metadata = docModelMetadataValue(readme.DocMetadata{})
+	}
+}
+
+// docSetConfigured records the optional attributes set in the configuration or the body front
+// matter in the private state so they're cleared when they're removed.
+func docSetConfigured(
+	ctx context.Context,
+	config docModel,
+	setKey func(context.Context, string, []byte) diag.Diagnostics,
+) diag.Diagnostics {
+	configured, ok := docConfigured(ctx, config)
+	if !ok {
+		return nil
+	}
+
+	value, err := json.Marshal(configured)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unable to save the configured attributes.", err.Error())
+
+		return diags
+	}
+
+	return setKey(ctx, docConfiguredKey, value)
+}
+
+// slugMatter returns true if the `slug` front matter key is set in the configured body.
+func (r *docResource) slugMatter(ctx context.Context, config docModel) bool {
+	body, _, err := frontmatter.RenderBody(ctx, config.Body.StringValue, config.BodyFile, config.TemplateVars, config.SnippetDirs)
+	if err != nil {
+		return false
+	}
+
+	value, _ := frontmatter.GetValue(ctx, body, "Slug")
+
+	return value != (reflect.Value{})
+}
+
+// docPlanToParams maps plan attributes to a `docWriteParams` struct to create or update a doc.
+//
+// The `body` parameter is the doc body resolved from the `body` or `body_file` attribute.
+//
+// Optional attributes that aren't known in the plan aren't sent so the API keeps their current
+// values.
+func docPlanToParams(ctx context.Context, plan docModel, body string) docWriteParams {
+	params := docWriteParams{
+		DocParams: readme.DocParams{
+			Body:   body,
+			Hidden: plan.Hidden.ValueBoolPointer(),
+			Order:  intPoint(int(plan.Order.ValueInt64())),
+			Title:  plan.Title.ValueString(),
+			Type:   plan.Type.ValueString(),
+		},
+		Deprecated:   knownBoolPoint(plan.Deprecated),
+		Excerpt:      knownStringPoint(plan.Excerpt),
+		Icon:         knownStringPoint(plan.Icon),
+		LinkExternal: knownBoolPoint(plan.LinkExternal),
+		LinkURL:      knownStringPoint(plan.LinkURL),
+	}
+
+	if !plan.Error.IsNull() && !plan.Error.IsUnknown() {
+		var docErr docErrorPlan
+		plan.Error.As(ctx, &docErr, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+		params.Error = readme.DocErrorObject{Code: docErr.Code.ValueString()}
+	}
+
+	// A null metadata object is sent as empty metadata to clear it.
+	if plan.Metadata.IsNull() {
+		params.Metadata = &docMetadataParams{Image: []string{}}
+	} else if !plan.Metadata.IsUnknown() {
+		var metadata docMetadataPlan
+		plan.Metadata.As(ctx, &metadata, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})
+
+		params.Metadata = &docMetadataParams{
+			Description: metadata.Description.ValueString(),
+			Image:       []string{},
+			Title:       metadata.Title.ValueString(),
+		}
+		if !metadata.Image.IsUnknown() {
+			metadata.Image.ElementsAs(ctx, &params.Metadata.Image, false)
+		}
 	}
 
 	// Only use one of Category or CategorySlug.
@@ -273,6 +548,10 @@ func (r *docResource) Create(
 	req resource.CreateRequest,
 	resp *resource.CreateResponse,
 ) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "doc") {
+		return
+	}
+
 	var err error
 	var doc readme.Doc
 	var apiResponse *readme.APIResponse
@@ -285,6 +564,24 @@ func (r *docResource) Create(
 		return
 	}
 
+	body, bodyHash, err := applyBody(plan.Body.StringValue, plan.BodyFile, plan.BodyHash)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body_file"), "Unable to create doc.", err.Error())
+
+		return
+	}
+	plan.BodyHash = bodyHash
+
+	body, bodyRendered, err := applyBodyTemplate(
+		ctx, body, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs, plan.BodyRendered,
+	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Unable to create doc.", err.Error())
+
+		return
+	}
+	plan.BodyRendered = bodyRendered
+
 	requestOpts := apiRequestOptions(plan.Version)
 	tflog.Info(ctx, fmt.Sprintf("creating doc with request options=%+v", requestOpts))
 
@@ -298,12 +595,31 @@ func (r *docResource) Create(
 		}
 	}
 
+	// Serialize writes to the category with other docs in it.
+	unlock := r.locks.lock(ctx, r.categoryLockKey(ctx, plan))
+	defer unlock()
+
+	// A `use_slug` set from the `slug` front matter key adopts the doc if it exists. Otherwise, the
+	// doc is created with the slug.
+	var configUseSlug types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("use_slug"), &configUseSlug)...)
+
 	useSlug := plan.UseSlug.ValueString() != "" && plan.UseSlug.ValueString() != "null"
 	exists := false
 	if useSlug {
-		exists, err = r.docExists(ctx, plan.UseSlug.ValueString(), requestOpts)
+		exists, apiResponse, err = r.docExists(ctx, plan.UseSlug.ValueString(), requestOpts)
+		if err != nil && configUseSlug.IsNull() &&
+			apiResponse != nil && apiResponse.HTTPResponse.StatusCode == 404 {
+			tflog.Info(ctx, fmt.Sprintf("doc %s from the front matter slug not found, creating it", plan.UseSlug))
+			err = nil
+		}
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to create doc.", clientError(err, apiResponse))
+			resp.Diagnostics.Append(clientDiagnostic(
+				"Unable to create doc.",
+				err,
+				apiResponse,
+				nil,
+			))
 
 			return
 		}
@@ -311,7 +627,7 @@ func (r *docResource) Create(
 
 	if exists {
 		// Adopt the doc.
-		adopted, err := r.adoptDoc(ctx, plan, requestOpts)
+		adopted, err := r.adoptDoc(ctx, plan, body, requestOpts)
 		if err != nil {
 			hint := fmt.Sprintf("\nHint: A value for the `use_slug` attribute is set to '%s', "+
 				"but the doc could not be found. Ensure the doc exists and the slug is correct. "+
@@ -328,16 +644,21 @@ func (r *docResource) Create(
 		doc = *adopted
 	} else {
 		// Create the doc.
-		doc, apiResponse, err = r.client.Doc.Create(docPlanToParams(ctx, plan), requestOpts)
+		doc, apiResponse, err = saveDoc(r.client, "", plan.Slug.ValueString(), docPlanToParams(ctx, plan, body), requestOpts)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to create doc.", clientError(err, apiResponse))
+			resp.Diagnostics.Append(clientDiagnostic(
+				"Unable to create doc.",
+				err,
+				apiResponse,
+				nil,
+			))
 
 			return
 		}
 	}
 
 	// Get the doc.
-	state, _, err = getDoc(r.client, ctx, doc.Slug, plan, requestOpts)
+	state, _, err = getDoc(r.client, r.lookups, ctx, doc.Slug, plan, requestOpts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create doc.",
@@ -348,7 +669,7 @@ func (r *docResource) Create(
 	}
 
 	// Get the doc a second time to ensure the state is fully populated.
-	state, _, err = getDoc(r.client, ctx, doc.Slug, state, requestOpts)
+	state, _, err = getDoc(r.client, r.lookups, ctx, doc.Slug, state, requestOpts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create doc.",
@@ -358,11 +679,18 @@ func (r *docResource) Create(
 		return
 	}
 
+	// The planned body is kept after apply. Changes ReadMe makes to it are compared when refreshing.
+	state.Body = plan.Body
+
 	// Set state to fully populated data.
 	if state.UseSlug.ValueString() == "" || state.UseSlug.ValueString() == "null" {
 		state.UseSlug = types.StringValue(state.Slug.ValueString())
 	}
 
+	var config docModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(docSetConfigured(ctx, config, resp.Private.SetKey)...)
+
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -374,16 +702,16 @@ func (r *docResource) Create(
 }
 
 func (r *docResource) docExists(
-	ctx context.Context,
+	_ context.Context,
 	slug string,
 	options readme.RequestOptions,
-) (bool, error) {
-	_, _, err := r.client.Doc.Get(slug, options)
+) (bool, *readme.APIResponse, error) {
+	_, apiResponse, err := r.client.Doc.Get(slug, options)
 	if err != nil {
-		return false, err
+		return false, apiResponse, err
 	}
 
-	return true, nil
+	return true, apiResponse, nil
 }
 
 // adoptDoc attempts to retrieve a doc by its slug and update it with the plan attributes.
@@ -391,11 +719,12 @@ func (r *docResource) docExists(
 func (r *docResource) adoptDoc(
 	ctx context.Context,
 	plan docModel,
+	body string,
 	requestOpts readme.RequestOptions,
 ) (*readme.Doc, error) {
 	slug := plan.UseSlug.ValueString()
 	tflog.Info(ctx, fmt.Sprintf("using slug %s", slug))
-	existing, _, err := getDoc(r.client, ctx, slug, plan, requestOpts)
+	existing, _, err := getDoc(r.client, r.lookups, ctx, slug, plan, requestOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve doc '%s': %w", slug, err)
 	}
@@ -406,7 +735,8 @@ func (r *docResource) adoptDoc(
 
 	// Update the existing doc.
 	tflog.Info(ctx, fmt.Sprintf("updating doc %s", slug))
-	doc, _, err := r.client.Doc.Update(slug, docPlanToParams(ctx, plan), requestOpts)
+	doc, _, err := saveDoc(r.client, slug, plan.Slug.ValueString(), docPlanToParams(ctx, plan, body), requestOpts)
+	r.lookups.invalidate(lookupDoc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update doc '%s': %w", slug, err)
 	}
@@ -431,24 +761,29 @@ func (r *docResource) Read(
 	slug := state.Slug.ValueString()
 	stateID := state.ID.ValueString()
 
-	if state.UseSlug.ValueString() != "" {
-		tflog.Info(ctx, fmt.Sprintf("use_slug is set to %s.", state.UseSlug.ValueString()))
-		slug = state.UseSlug.ValueString()
-	}
-
 	requestOpts := apiRequestOptions(state.Version)
 	logMsg := fmt.Sprintf("retrieving doc %s with request options=%+v", slug, requestOpts)
 	tflog.Info(ctx, logMsg)
 
 	// Get the doc.
-	state, apiResponse, err := getDoc(r.client, ctx, slug, state, requestOpts)
-	if err != nil { // nolint:nestif // TODO: refactor
-		if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == 404 {
+	doc, apiResponse, err := getDoc(r.client, r.lookups, ctx, slug, state, requestOpts)
+
+	// The doc is tracked by its ID. If the slug now belongs to another doc, the doc was renamed.
+	moved := err == nil && stateID != "" && doc.ID.ValueString() != stateID
+	if moved {
+		tflog.Info(ctx, fmt.Sprintf("doc %s has a different ID than %s", slug, stateID))
+	} else if err == nil {
+		state = doc
+	}
+
+	if err != nil || moved { // nolint:nestif // TODO: refactor
+		if moved || (apiResponse != nil && apiResponse.HTTPResponse.StatusCode == 404) {
 			// Attempt to find the doc by ID by searching all docs.
 			// While the slug is the primary identifier to request a doc, the
-			// slug is not stable and can be changed through the web UI.
+			// slug is not stable and can be changed through the web UI. A doc
+			// found by its ID with a new slug is reported as drift.
 			tflog.Info(ctx, fmt.Sprintf("doc %s not found when looking up by slug, performing search", slug))
-			state, apiResponse, err = getDoc(r.client, ctx, IDPrefix+stateID, state, requestOpts)
+			state, apiResponse, err = getDoc(r.client, r.lookups, ctx, IDPrefix+stateID, state, requestOpts)
 			if err != nil {
 				if strings.Contains(err.Error(), "no doc found matching id") ||
 					strings.Contains(
@@ -464,14 +799,20 @@ func (r *docResource) Read(
 
 					return
 				}
-				hint := "Hint: If you changed the doc slug using the web UI, set the `use_slug` " +
-					"attribute or the `slug` frontmatter key to the new slug.\n"
+				hint := "Hint: If you changed the doc slug using the web UI and the doc is hidden, it can't be " +
+					"found by searching. Set the `slug` attribute or the `slug` front matter key to the new slug, " +
+					"or import the doc again.\n"
 				resp.Diagnostics.AddWarning("Unable to search for doc.", hint+clientError(err, apiResponse))
 
 				return
 			}
 		} else {
-			resp.Diagnostics.AddError("Unable to retrieve doc.", clientError(err, apiResponse))
+			resp.Diagnostics.Append(clientDiagnostic(
+				"Unable to retrieve doc.",
+				err,
+				apiResponse,
+				nil,
+			))
 
 			return
 		}
@@ -496,6 +837,10 @@ func (r *docResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
+	if r.config.readOnly(&resp.Diagnostics, "update", "doc") {
+		return
+	}
+
 	// Retrieve values from plan and current state.
 	var plan, state docModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -504,6 +849,24 @@ func (r *docResource) Update(
 		return
 	}
 
+	body, bodyHash, err := applyBody(plan.Body.StringValue, plan.BodyFile, plan.BodyHash)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body_file"), "Unable to update doc.", err.Error())
+
+		return
+	}
+	plan.BodyHash = bodyHash
+
+	body, bodyRendered, err := applyBodyTemplate(
+		ctx, body, plan.BodyFile, plan.TemplateVars, plan.SnippetDirs, plan.BodyRendered,
+	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Unable to update doc.", err.Error())
+
+		return
+	}
+	plan.BodyRendered = bodyRendered
+
 	requestOpts := apiRequestOptions(plan.Version)
 
 	// If a parent doc is set, verify that it exists.
@@ -517,24 +880,26 @@ func (r *docResource) Update(
 	}
 	slug := state.Slug.ValueString()
 
-	if state.UseSlug.ValueString() != "" {
-		tflog.Info(ctx, fmt.Sprintf("use_slug is set to %s.", state.UseSlug.ValueString()))
-		slug = state.UseSlug.ValueString()
-	}
+	// Serialize writes to the current and new categories with other docs in them.
+	unlock := r.locks.lock(ctx, r.categoryLockKey(ctx, state), r.categoryLockKey(ctx, plan))
+	defer unlock()
 
 	tflog.Info(ctx, fmt.Sprintf("updating doc %s with request options=%+v", slug, requestOpts))
 
 	// Update the doc.
-	params := docPlanToParams(ctx, plan)
-	response, apiResponse, err := r.client.Doc.Update(slug, params, requestOpts)
+	params := docPlanToParams(ctx, plan, body)
+	response, apiResponse, err := saveDoc(r.client, slug, plan.Slug.ValueString(), params, requestOpts)
+	// The doc slug may have changed.
+	r.lookups.invalidate(lookupDoc)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to update doc.", clientError(err, apiResponse))
+		resp.Diagnostics.Append(clientDiagnostic("Unable to update doc.", err, apiResponse, nil))
 
 		return
 	}
 
 	// Get the doc.
-	plan, _, err = getDoc(r.client, ctx, response.Slug, plan, requestOpts)
+	plannedBody := plan.Body
+	plan, _, err = getDoc(r.client, r.lookups, ctx, response.Slug, plan, requestOpts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update doc.",
@@ -549,7 +914,7 @@ func (r *docResource) Update(
 	}
 
 	// Get the doc a second time to ensure the state is fully populated.
-	plan, _, err = getDoc(r.client, ctx, response.Slug, plan, requestOpts)
+	plan, _, err = getDoc(r.client, r.lookups, ctx, response.Slug, plan, requestOpts)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update doc.",
@@ -563,11 +928,24 @@ func (r *docResource) Update(
 		return
 	}
 
+	// Record the previous slug when the slug changed.
+	if response.Slug != slug {
+		tflog.Info(ctx, fmt.Sprintf("doc slug changed from %s to %s", slug, response.Slug))
+		plan.PreviousSlug = types.StringValue(slug)
+	}
+
+	// The planned body is kept after apply. Changes ReadMe makes to it are compared when refreshing.
+	plan.Body = plannedBody
+
 	// Set state to fully populated data.
 	if plan.UseSlug.ValueString() == "" || plan.UseSlug.ValueString() == "null" {
 		plan.UseSlug = types.StringValue(plan.Slug.ValueString())
 	}
 
+	var config docModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(docSetConfigured(ctx, config, resp.Private.SetKey)...)
+
 	// Set refreshed state.
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -581,6 +959,10 @@ func (r *docResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
+	if r.config.readOnly(&resp.Diagnostics, "delete", "doc") {
+		return
+	}
+
 	// Retrieve values from state.
 	var state docModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -590,90 +972,87 @@ func (r *docResource) Delete(
 
 	requestOpts := apiRequestOptions(state.Version)
 
+	// Serialize writes to the category with other docs in it.
+	unlock := r.locks.lock(ctx, r.categoryLockKey(ctx, state))
+	defer unlock()
+
 	// Check the category's docs to find the doc and its children.
 	docs, _, err := r.client.Category.GetDocs(state.CategorySlug.ValueString(), requestOpts)
 	if err != nil {
 		resp.Diagnostics.AddWarning("Unable to retrieve category docs.", clientError(err, nil))
 	}
 
-	// Gather the slugs of the docs to delete, including the parent and its children.
-	slugsToDelete, parentDoc := r.identifyDocsToDelete(ctx, state.Slug.ValueString(), docs, resp)
-	if parentDoc == nil {
+	// Find the doc at any depth in the category's doc tree.
+	node, found := findCategoryDoc(docs, state.ID.ValueString(), state.Slug.ValueString())
+	if !found {
+		resp.Diagnostics.AddWarning(
+			"Doc not found",
+			fmt.Sprintf("The doc with slug '%s' was not found in the retrieved category docs.",
+				state.Slug.ValueString()),
+		)
+
 		return
 	}
 
 	// Ensure children are handled correctly.
 	// Delete them first if DestroyChildDocs is enabled. Otherwise, return.
-	if !r.handleChildDocs(ctx, resp, state.Slug.ValueString(), parentDoc) {
+	childSlugs := categoryDocDeleteOrder(node.doc)
+	if !r.handleChildDocs(resp, node.doc) {
 		return
 	}
 
-	// Perform deletions
-	r.deleteDocs(ctx, resp, slugsToDelete, requestOpts)
+	// Perform deletions, starting with the deepest child docs.
+	deleted, ok := r.deleteChildDocs(ctx, resp, childSlugs, requestOpts)
 
-	// Warn if child docs were deleted
-	if len(slugsToDelete) > 1 && r.config.DestroyChildDocs.ValueBool() {
+	// Warn if child docs were deleted.
+	if len(deleted) > 0 {
 		resp.Diagnostics.AddWarning(
-			fmt.Sprintf("Doc '%s' and its child docs were destroyed!", state.Slug.ValueString()),
+			fmt.Sprintf("Child docs of doc '%s' were destroyed!", node.doc.Slug),
 			fmt.Sprintf("The provider configuration 'config.destroy_child_docs' is set to true. "+
-				"Child docs were deleted before the parent doc '%s': %s", state.Slug.ValueString(),
-				strings.Join(slugsToDelete[1:], ", ")),
+				"Child docs were deleted before the parent doc '%s': %s", node.doc.Slug,
+				strings.Join(deleted, ", ")),
 		)
 	}
-}
-
-// identifyDocsToDelete finds the doc and its children to delete.
-func (r *docResource) identifyDocsToDelete(
-	ctx context.Context,
-	slug string,
-	docs []readme.CategoryDocs,
-	resp *resource.DeleteResponse,
-) ([]string, *readme.CategoryDocs) {
-	var slugsToDelete []string
-	var parentDoc *readme.CategoryDocs
-
-	for _, doc := range docs {
-		if parentDoc, slugsToDelete = r.matchDocAndChildren(doc, slug); parentDoc != nil {
-			break
-		}
-	}
 
-	if parentDoc == nil {
-		resp.Diagnostics.AddWarning(
-			"Doc not found",
-			fmt.Sprintf("The doc with slug '%s' was not found in the retrieved category docs.", slug),
-		)
+	if ok {
+		r.deleteDoc(ctx, resp, node.doc.Slug, requestOpts)
 	}
+}
 
-	return slugsToDelete, parentDoc
+// hasDefaultCategory determines if the provider is configured with a default category slug.
+//
+// This is always true when the provider isn't configured yet, such as when validating the
+// configuration, since the default can't be checked until then.
+func (r docResource) hasDefaultCategory() bool {
+	return r.client == nil || r.config.DefaultCategorySlug.ValueString() != ""
 }
 
-// matchDocAndChildren finds the doc and its children in the category docs.
-func (r *docResource) matchDocAndChildren(doc readme.CategoryDocs, slug string) (*readme.CategoryDocs, []string) {
-	if doc.Slug == slug {
-		return &doc, r.collectDocSlugs(doc)
-	}
-	for _, child := range doc.Children {
-		if child.Slug == slug {
-			return &child, r.collectDocSlugs(child)
-		}
-		for _, grandchild := range child.Children {
-			if grandchild.Slug == slug {
-				return &grandchild, r.collectDocSlugs(grandchild)
-			}
+// categoryLockKey returns the key used to serialize writes to a doc's category.
+//
+// The category slug is used when it's known. Otherwise, the slug is resolved from the category ID
+// so that docs referencing the same category by ID or by slug share a lock.
+func (r *docResource) categoryLockKey(ctx context.Context, model docModel) string {
+	categorySlug := model.CategorySlug.ValueString()
+
+	if categorySlug == "" && model.Category.ValueString() != "" {
+		slug, apiResponse, err := r.lookups.categorySlug(
+			r.client,
+			model.Category.ValueString(),
+			apiRequestOptions(model.Version),
+		)
+		if err != nil {
+			tflog.Info(ctx, fmt.Sprintf(
+				"unable to resolve category slug for lock, using category ID: %s",
+				clientError(err, apiResponse),
+			))
+
+			return categoryLockKey(model.Version.ValueString(), IDPrefix+model.Category.ValueString())
 		}
-	}
-	return nil, nil
-}
 
-// collectDocSlugs collects the slugs of the doc and its children.
-func (r *docResource) collectDocSlugs(doc readme.CategoryDocs) []string {
-	var slugs []string
-	slugs = append(slugs, doc.Slug)
-	for _, child := range doc.Children {
-		slugs = append(slugs, r.collectDocSlugs(child)...)
+		categorySlug = slug
 	}
-	return slugs
+
+	return categoryLockKey(model.Version.ValueString(), categorySlug)
 }
 
 // handleChildDocs ensures that child docs are handled correctly before
@@ -681,41 +1060,63 @@ func (r *docResource) collectDocSlugs(doc readme.CategoryDocs) []string {
 // 'config.destroy_child_docs' is set to true, child docs will be deleted
 // first. If child docs exist and 'config.destroy_child_docs' is false, an
 // error will be returned.
-func (r *docResource) handleChildDocs(
-	ctx context.Context,
-	resp *resource.DeleteResponse,
-	slug string,
-	parentDoc *readme.CategoryDocs,
-) bool {
-	if len(parentDoc.Children) > 0 && !r.config.DestroyChildDocs.ValueBool() {
-		childSlugs := make([]string, 0, len(parentDoc.Children))
-		for _, child := range parentDoc.Children {
-			childSlugs = append(childSlugs, child.Slug)
+func (r *docResource) handleChildDocs(resp *resource.DeleteResponse, parentDoc readme.CategoryDocs) bool {
+	descendants := categoryDocDescendants(parentDoc)
+	if len(descendants) > 0 && !r.config.DestroyChildDocs.ValueBool() {
+		childSlugs := make([]string, 0, len(descendants))
+		for _, child := range descendants {
+			childSlugs = append(childSlugs, child.doc.Slug)
 		}
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Unable to delete doc '%s' because it has child docs.", slug),
+			fmt.Sprintf("Unable to delete doc '%s' because it has child docs.", parentDoc.Slug),
 			"Child docs must be deleted first. Set the provider 'config.destroy_child_docs' "+
 				"option to true or delete child docs first.\n"+
 				fmt.Sprintf("Docs that were found: %s", strings.Join(childSlugs, ", ")),
 		)
+
 		return false
 	}
+
 	return true
 }
 
-// deleteDocs deletes the docs in the slugsToDelete slice.
-func (r *docResource) deleteDocs(
+// deleteChildDocs deletes the child docs in the order of the slugs, which should have the deepest
+// docs first.
+//
+// The slugs of the docs that were deleted are returned, along with false if a doc couldn't be
+// deleted. Child docs that no longer exist are skipped.
+func (r *docResource) deleteChildDocs(
 	ctx context.Context,
 	resp *resource.DeleteResponse,
-	slugsToDelete []string,
+	slugs []string,
 	requestOpts readme.RequestOptions,
-) {
-	for i := len(slugsToDelete) - 1; i >= 0; i-- {
-		slug := slugsToDelete[i]
-		if !r.deleteDoc(ctx, resp, slug, requestOpts) {
-			return
+) ([]string, bool) {
+	deleted := []string{}
+
+	for _, slug := range slugs {
+		tflog.Info(ctx, fmt.Sprintf("deleting child doc with slug %s and request options=%+v", slug, requestOpts))
+		_, apiResponse, err := r.client.Doc.Delete(slug, requestOpts)
+		r.lookups.invalidate(lookupDoc)
+		if err != nil {
+			if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == 404 {
+				tflog.Info(ctx, fmt.Sprintf("child doc %s not found when deleting, skipping", slug))
+
+				continue
+			}
+			resp.Diagnostics.Append(clientDiagnostic(
+				fmt.Sprintf("Unable to delete child doc with slug '%s'", slug),
+				err,
+				apiResponse,
+				nil,
+			))
+
+			return deleted, false
 		}
+
+		deleted = append(deleted, slug)
 	}
+
+	return deleted, true
 }
 
 // deleteDoc deletes the doc with the specified slug.
@@ -745,6 +1146,7 @@ func (r *docResource) deleteDoc(
 
 	tflog.Info(ctx, fmt.Sprintf("deleting doc with slug %s and request options=%+v", slug, requestOpts))
 	_, apiResponse, err = r.client.Doc.Delete(slug, requestOpts)
+	r.lookups.invalidate(lookupDoc)
 	if err != nil {
 		if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == 404 {
 			tflog.Info(ctx, fmt.Sprintf("doc %s not found when deleting, removing from state", slug))
@@ -755,10 +1157,12 @@ func (r *docResource) deleteDoc(
 			)
 			return false
 		}
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(clientDiagnostic(
 			fmt.Sprintf("Unable to delete doc with slug '%s'", slug),
-			clientError(err, apiResponse),
-		)
+			err,
+			apiResponse,
+			nil,
+		))
 		return false
 	}
 
@@ -771,8 +1175,51 @@ func (r *docResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	// Import by slug.
-	resource.ImportStatePassthroughID(ctx, path.Root("slug"), req, resp)
+	id, err := parseImportID(req.ID, r.config.DefaultVersion.ValueString(), true)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID.", err.Error())
+
+		return
+	}
+
+	slug := id.identifier
+
+	// Docs in a category may be matched by their title.
+	if id.category != "" {
+		docs, apiResponse, err := r.client.Category.GetDocs(id.category, id.options())
+		if err != nil {
+			resp.Diagnostics.Append(clientDiagnostic("Unable to import doc.", err, apiResponse, nil))
+
+			return
+		}
+
+		match, err := matchImportID("doc", id, categoryDocsMatches(docs))
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to import doc.", err.Error())
+
+			return
+		}
+		slug = match.slug
+	}
+
+	doc, apiResponse, err := r.client.Doc.Get(slug, id.options())
+	if err != nil {
+		resp.Diagnostics.Append(clientDiagnostic("Unable to import doc.", err, apiResponse, nil))
+
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("importing doc %s with version '%s'", doc.Slug, id.version))
+
+	// The category slug and parent doc slug are resolved when the doc is read.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), doc.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("slug"), doc.Slug)...)
+	if id.category != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("category_slug"), id.category)...)
+	}
+	if id.version != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("version"), id.version)...)
+	}
 }
 
 // docValidParent verifies that a parent doc exists if the `parent_doc` or `parent_doc_slug` attributes are set.
@@ -784,14 +1231,35 @@ func (r *docResource) ImportState(
 //
 // If the attributes are set and the doc does not exist, return false and a string stating that it doesn't exist
 // for use in the response diag error.
+//
+// The parent is looked up in the category's doc tree first, which finds hidden parents at any depth
+// and prevents a doc from being its own ancestor. If it isn't found there, the doc is retrieved
+// directly.
 func (r *docResource) docValidParent(
 	ctx context.Context,
 	plan docModel,
 	options readme.RequestOptions,
 ) (bool, string) {
-	if plan.ParentDoc.ValueString() != "" {
-		attrVal := IDPrefix + plan.ParentDoc.ValueString()
-		_, _, err := r.client.Doc.Get(attrVal, options)
+	parentID := plan.ParentDoc.ValueString()
+	parentSlug := plan.ParentDocSlug.ValueString()
+	if parentID == "" && parentSlug == "" {
+		return true, ""
+	}
+
+	if plan.CategorySlug.ValueString() != "" {
+		docs, apiResponse, err := r.client.Category.GetDocs(plan.CategorySlug.ValueString(), options)
+		if err != nil {
+			tflog.Info(ctx, fmt.Sprintf(
+				"unable to retrieve category docs to verify the parent doc: %s", clientError(err, apiResponse),
+			))
+		} else if parent, ok := findCategoryDoc(docs, parentID, parentSlug); ok {
+			return docParentNotDescendant(docs, plan.ID.ValueString(), parent)
+		}
+	}
+
+	if parentID != "" {
+		attrVal := IDPrefix + parentID
+		_, _, err := r.lookups.docSlug(r.client, parentID, options)
 		if err != nil {
 			return false,
 				fmt.Sprintf(`Could not find parent_doc matching "%s" (is it hidden?)`+
@@ -799,8 +1267,8 @@ func (r *docResource) docValidParent(
 					`"verify_parent_doc" to false.`, attrVal,
 				)
 		}
-	} else if plan.ParentDocSlug.ValueString() != "" {
-		attrVal := plan.ParentDocSlug.ValueString()
+	} else if parentSlug != "" {
+		attrVal := parentSlug
 		_, _, err := r.client.Doc.Get(attrVal, options)
 		if err != nil {
 			return false, fmt.Sprintf("Could not find parent_doc_slug matching %s", attrVal)
@@ -810,6 +1278,30 @@ func (r *docResource) docValidParent(
 	return true, ""
 }
 
+// docParentNotDescendant verifies that a parent doc found in a category's doc tree isn't the doc
+// with the specified ID or one of its child docs at any depth.
+func docParentNotDescendant(docs []readme.CategoryDocs, id string, parent categoryDocNode) (bool, string) {
+	doc, ok := findCategoryDoc(docs, id, "")
+	if !ok {
+		return true, ""
+	}
+
+	if parent.doc.ID == doc.doc.ID {
+		return false, fmt.Sprintf("The doc '%s' can't be its own parent.", doc.doc.Slug)
+	}
+
+	for _, child := range categoryDocDescendants(doc.doc) {
+		if child.doc.ID == parent.doc.ID {
+			return false, fmt.Sprintf(
+				"The parent doc '%s' is a child of the doc '%s' and can't also be its parent.",
+				parent.doc.Slug, doc.doc.Slug,
+			)
+		}
+	}
+
+	return true, ""
+}
+
 // Schema for the readme_doc resource.
 func (r *docResource) Schema(
 	_ context.Context,
@@ -938,19 +1430,33 @@ func (r *docResource) Schema(
 				},
 			},
 			"body": schema.StringAttribute{
+				CustomType: markdown.Type{},
 				Description: "The body content of the doc, formatted in ReadMe or GitHub flavored Markdown. " +
 					"Accepts long page content, for example, greater than 100k characters. " +
-					"Optionally use front matter to set certain attributes.",
+					"Optionally use front matter to set certain attributes." + bodyFormattingDescription,
 				Computed: true,
 				Optional: true,
 			},
 			"body_clean": schema.StringAttribute{
 				Description: "The body content of the doc after transformations such as trimming leading and trailing" +
-					"spaces.",
-				Computed: true,
+					"spaces. This is null when `body_file` is set.",
+				Computed:           true,
+				DeprecationMessage: bodyCleanDeprecation,
+			},
+			"body_file": schema.StringAttribute{
+				Description: bodyFileDescription,
+				Optional:    true,
+			},
+			"body_hash": schema.StringAttribute{
+				Description: bodyHashDescription,
+				Computed:    true,
 			},
 			"body_html": schema.StringAttribute{
-				Description: "The body content in HTML.",
+				Description: "The body content in HTML. This is null when `body_file` is set.",
+				Computed:    true,
+			},
+			"body_rendered": schema.StringAttribute{
+				Description: bodyRenderedDescription,
 				Computed:    true,
 			},
 			"category": schema.StringAttribute{
@@ -967,7 +1473,8 @@ func (r *docResource) Schema(
 			"category_slug": schema.StringAttribute{
 				Description: "**Required**. The category slug of the doc. Note that changing the category will result " +
 					"in a replacement of the doc resource. Alternatively, set the `categorySlug` key the body front matter. " +
-					"Docs that specify a `parent_doc` or `parent_doc_slug` will use their parent's category.",
+					"Docs that specify a `parent_doc` or `parent_doc_slug` will use their parent's category. " +
+					"If no category is set, the provider's `config.default_category_slug` is used.",
 				Computed: true,
 				Optional: true,
 				PlanModifiers: []planmodifier.String{
@@ -982,22 +1489,38 @@ func (r *docResource) Schema(
 				},
 			},
 			"deprecated": schema.BoolAttribute{
-				Description: "Identifies if a doc is deprecated or not.",
-				Computed:    true,
+				Description: "Toggles if a doc is deprecated or not. " +
+					"This attribute may be set in the body front matter.",
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.Bool{
+					frontmatter.GetBool("Deprecated"),
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"error": schema.SingleNestedAttribute{
 				Description: "Error code configuration for a doc. This attribute may be set in the body front matter.",
+				Computed:    true,
 				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"code": schema.StringAttribute{
-						Description: "",
+						Description: "The error code for docs with the type set to `error`.",
+						Computed:    true,
 						Optional:    true,
 					},
 				},
 			},
 			"excerpt": schema.StringAttribute{
-				Description: "A short summary of the content.",
+				Description: "A short summary of the content. This attribute may be set in the body front matter.",
 				Computed:    true,
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					frontmatter.GetString("Excerpt"),
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"hidden": schema.BoolAttribute{
 				Description: "Toggles if a doc is hidden or not. This attribute may be set in the body front matter.",
@@ -1008,7 +1531,14 @@ func (r *docResource) Schema(
 				},
 			},
 			"icon": schema.StringAttribute{
+				Description: "The icon shown next to the doc in the sidebar. " +
+					"This attribute may be set in the body front matter.",
 				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					frontmatter.GetString("Icon"),
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"id": schema.StringAttribute{
 				Description: "The ID of the doc.",
@@ -1031,30 +1561,53 @@ func (r *docResource) Schema(
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"link_check": schema.StringAttribute{
+				Description: linkCheckDescription,
+				Optional:    true,
+			},
 			"link_external": schema.BoolAttribute{
-				Description: "Identifies a doc's link as external or not.",
-				Computed:    true,
+				Description: "Toggles if a `link` doc opens its URL in a new tab. " +
+					"This attribute may be set in the body front matter.",
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.Bool{
+					frontmatter.GetBool("LinkExternal"),
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"link_url": schema.StringAttribute{
-				Description: "The URL of the doc.",
-				Computed:    true,
+				Description: "The URL a doc with the type set to `link` redirects to. " +
+					"This attribute may be set in the body front matter.",
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					frontmatter.GetString("LinkURL"),
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"metadata": schema.SingleNestedAttribute{
-				Description: "Metadata about the doc.",
+				Description: "The SEO metadata of the doc. This attribute may be set in the body front matter.",
 				Computed:    true,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"description": schema.StringAttribute{
-						Description: "The description of the doc.",
+						Description: "The SEO description of the doc.",
 						Computed:    true,
+						Optional:    true,
 					},
 					"image": schema.ListAttribute{
-						Description: "An image associated with the doc.",
+						Description: "The URLs of the images shown when the doc is shared.",
 						Computed:    true,
+						Optional:    true,
 						ElementType: types.StringType,
 					},
 					"title": schema.StringAttribute{
-						Description: "The title of the doc.",
+						Description: "The SEO title of the doc.",
 						Computed:    true,
+						Optional:    true,
 					},
 				},
 			},
@@ -1125,9 +1678,18 @@ func (r *docResource) Schema(
 					frontmatter.GetString("ParentDocSlug"),
 				},
 			},
+			"path": schema.StringAttribute{
+				Description: "This is an unused attribute in the resource that is present to " +
+					"satisfy the model shared with the doc data source. It may be removed in the future.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"previous_slug": schema.StringAttribute{
-				Description: "If the doc's slug has changed, this attribute contains the previous slug.",
-				Computed:    true,
+				Description: "If the doc's slug has changed, this attribute contains the previous slug. " +
+					"The provider sets this when it changes the `slug`.",
+				Computed: true,
 			},
 			"project": schema.StringAttribute{
 				Description: "The ID of the project the doc is in.",
@@ -1141,16 +1703,35 @@ func (r *docResource) Schema(
 				Computed:    true,
 			},
 			"slug": schema.StringAttribute{
-				Description: "The slug of the doc.",
-				Computed:    true,
+				Description: "The slug of the doc. Changing the slug updates the doc in place and records the " +
+					"previous slug in `previous_slug`. If not set, ReadMe generates the slug from the title. " +
+					"This attribute may be set in the body front matter with the `slug` key. " +
+					"The provider tracks the doc by its ID, so a slug changed in the web UI is shown as a change " +
+					"to this attribute.",
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					frontmatter.GetString("Slug"),
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"slug_updated_at": schema.StringAttribute{
 				Description: "The timestamp of when the doc's slug was last updated.",
 				Computed:    true,
 			},
+			"snippet_dirs": schema.ListAttribute{
+				Description: snippetDirsDescription,
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"sync_unique": schema.StringAttribute{
 				Computed: true,
 			},
+			"template_vars": schema.MapAttribute{
+				Description: templateVarsDescription,
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"title": schema.StringAttribute{
 				Description: "**Required.** The title of the doc." +
 					"This attribute may optionally be set in the body front matter.",
@@ -1180,19 +1761,21 @@ func (r *docResource) Schema(
 				Computed:    true,
 			},
 			"use_slug": schema.StringAttribute{
-				MarkdownDescription: "**Use with caution!** Create the doc resource by importing an existing doc by its slug. " +
+				MarkdownDescription: "**Use with caution!** Create the doc resource by adopting an existing doc by its slug. " +
 					"This is non-conventional and should only be used when the slug is known and " +
-					"the doc is not managed by Terraform or when the slug is changed in the web UI. " +
+					"the doc is not managed by Terraform. " +
 					"This is useful for managing an API specification's doc that gets created " +
 					"automatically by ReadMe. When set, the specified doc will be replaced " +
 					"with the Terraform-managed doc. " +
 					"If this is set and then unset, a new doc will be created but the existing doc will not be " +
 					"deleted. The existing doc will be orphaned and will not be managed by Terraform. " +
-					"If this is unset and then set, the existing doc will be deleted and the resource will be " +
-					"pointed to the specified doc. " +
 					"In the case of API specification docs, the doc is implicitly deleted when the " +
 					"API specification is deleted. " +
-					"This attribute may be set in the body front matter with the `slug` key.",
+					"To change the slug of a managed doc, or to follow a slug changed in the web UI, use the " +
+					"`slug` attribute instead. " +
+					"This attribute may be set in the body front matter with the `slug` key, which also sets the " +
+					"`slug` attribute. A new doc adopts an existing doc with the front matter slug, or is created " +
+					"with that slug if no such doc exists.",
 				Optional: true,
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
@@ -1200,9 +1783,10 @@ func (r *docResource) Schema(
 				},
 			},
 			"version": schema.StringAttribute{
-				Description: "The version to create the doc under.",
-				Optional:    true,
-				Computed:    true,
+				Description: "The version to create the doc under. Defaults to the provider's " +
+					"`config.default_version` if set.",
+				Optional: true,
+				Computed: true,
 			},
 			"version_id": schema.StringAttribute{
 				Description: "The version ID the doc is associated with.",
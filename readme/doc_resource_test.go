@@ -2,13 +2,19 @@
 package readme
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/markdown"
 	"gopkg.in/h2non/gock.v1"
 )
 
@@ -251,7 +257,34 @@ func TestDocResource(t *testing.T) {
 						Get("/categories/" + mockCategory.Slug + "/docs").
 						Times(1).Reply(200).JSON(mockCategoryDocs)
 					docCommonGocks()
-					gock.New(testURL).Get("/docs/" + mockDoc.Slug).Times(2).Reply(200).JSON(mockDoc)
+					gock.New(testURL).Get("/docs/" + mockDoc.Slug).Times(3).Reply(200).JSON(mockDoc)
+					gock.New(testURL).Delete("/docs/" + mockDoc.Slug).Times(1).Reply(204)
+				},
+			},
+
+			// 9. Test import with a version, category, and title.
+			{
+				ResourceName:  "readme_doc.test",
+				ImportState:   true,
+				ImportStateId: mockVersion.VersionClean + "/" + mockCategory.Slug + "/" + mockDoc.Title,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					state := states[0].Attributes
+					if state["slug"] != mockDoc.Slug || state["version"] != mockVersion.VersionClean ||
+						state["category_slug"] != mockCategory.Slug {
+						return fmt.Errorf("unexpected imported state: %v", state)
+					}
+
+					return nil
+				},
+				PreConfig: func() {
+					gock.OffAll()
+					gock.New(testURL).
+						Get("/categories/" + mockCategory.Slug + "/docs").
+						Times(1).Reply(200).JSON([]readme.CategoryDocs{
+						{ID: mockDoc.ID, Slug: mockDoc.Slug, Title: mockDoc.Title},
+					})
+					docCommonGocks()
+					gock.New(testURL).Get("/docs/" + mockDoc.Slug).Times(3).Reply(200).JSON(mockDoc)
 					gock.New(testURL).Delete("/docs/" + mockDoc.Slug).Times(1).Reply(204)
 				},
 			},
@@ -1320,3 +1353,419 @@ func TestDocRenamedSlugResource(t *testing.T) {
 		},
 	})
 }
+
+// TestDocResource_ProviderDefaults tests that the provider's default version and category slug are
+// used for a doc that doesn't set them.
+func TestDocResource_ProviderDefaults(t *testing.T) {
+	// Close all gocks after completion.
+	defer gock.OffAll()
+
+	providerConfig := fmt.Sprintf(`
+		provider "readme" {
+			api_token = "%s"
+			api_url   = "%s"
+			config = {
+				default_version       = "%s"
+				default_category_slug = "%s"
+			}
+		}`,
+		testToken, testURL, mockVersion.VersionClean, mockCategory.Slug,
+	)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+					resource "readme_doc" "test" {
+						title = "%s"
+						body  = "%s"
+						type  = "%s"
+					}`,
+					mockDoc.Title, mockDoc.Body, mockDoc.Type,
+				),
+				PreConfig: func() {
+					docCommonGocks()
+					// The doc is created in the default version.
+					gock.New(testURL).
+						Post("/docs").
+						MatchHeader("x-readme-version", mockVersion.VersionClean).
+						Times(1).
+						Reply(201).
+						JSON(mockDoc)
+					gock.New(testURL).Get("/docs/" + mockDoc.Slug).Persist().Reply(200).JSON(mockDoc)
+					gock.New(testURL).
+						Get("/categories/" + mockCategory.Slug + "/docs").
+						Persist().
+						Reply(200).
+						JSON(mockCategoryDocs)
+					gock.New(testURL).Delete("/docs/" + mockDoc.Slug).Times(1).Reply(204)
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_doc.test", "version", mockVersion.VersionClean),
+					resource.TestCheckResourceAttr("readme_doc.test", "category_slug", mockCategory.Slug),
+					resource.TestCheckResourceAttr("readme_doc.test", "category", mockCategory.ID),
+				),
+			},
+		},
+	})
+}
+
+// TestDocResource_Missing_Category tests that an error is returned when a doc doesn't set a
+// category and the provider doesn't have a default category slug.
+func TestDocResource_Missing_Category(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig + fmt.Sprintf(`
+					resource "readme_doc" "test" {
+						title = "%s"
+						body  = "%s"
+						type  = "%s"
+					}`,
+					mockDoc.Title, mockDoc.Body, mockDoc.Type,
+				),
+				ExpectError: regexp.MustCompile(`category or category_slug must be set`),
+			},
+		},
+	})
+}
+
+func TestSaveDoc(t *testing.T) {
+	defer gock.OffAll()
+
+	client, _ := readme.NewClient(testToken, testURL)
+	excerpt := "A short summary."
+	params := docWriteParams{
+		DocParams:  readme.DocParams{Title: mockDoc.Title, Category: mockDoc.Category},
+		Deprecated: boolPoint(false),
+		Excerpt:    &excerpt,
+	}
+
+	renamed := mockDoc
+	renamed.Slug = "renamed"
+
+	// The slug is only sent when it changes.
+	gock.New(testURL).Put("/docs/" + mockDoc.Slug).
+		BodyString(`"slug":"renamed"`).
+		Times(1).Reply(200).JSON(renamed)
+	gock.New(testURL).Post("/docs").
+		BodyString(`"slug":"renamed"`).
+		Times(1).Reply(201).JSON(renamed)
+	// Fields the API client doesn't support are sent when they're set.
+	gock.New(testURL).Put("/docs/renamed").
+		BodyString(`"deprecated":false,"excerpt":"A short summary."}`).
+		Times(1).Reply(200).JSON(renamed)
+
+	doc, _, err := saveDoc(client, mockDoc.Slug, "renamed", params, readme.RequestOptions{})
+	if err != nil || doc.Slug != "renamed" {
+		t.Errorf("expected the doc to be renamed, got '%s', %v", doc.Slug, err)
+	}
+
+	doc, _, err = saveDoc(client, "", "renamed", params, readme.RequestOptions{})
+	if err != nil || doc.Slug != "renamed" {
+		t.Errorf("expected the doc to be created with the slug, got '%s', %v", doc.Slug, err)
+	}
+
+	if _, _, err := saveDoc(client, "renamed", "renamed", params, readme.RequestOptions{}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if !gock.IsDone() {
+		t.Error("expected all mocked requests to be made")
+	}
+}
+
+// Test changing the slug of a doc in place and detecting a slug changed in the web UI.
+func TestDocResource_Slug_Changes(t *testing.T) {
+	// Close all gocks after completion.
+	defer gock.OffAll()
+
+	expectedDoc := mockDoc
+
+	renamedDoc := mockDoc
+	renamedDoc.Slug = "renamed"
+
+	config := func(slug string) string {
+		return testProviderConfig + fmt.Sprintf(`
+			resource "readme_doc" "test" {
+				title    = "%s"
+				body     = "%s"
+				category = "%s"
+				type     = "%s"
+				slug     = "%s"
+			}`,
+			expectedDoc.Title, expectedDoc.Body, expectedDoc.Category, expectedDoc.Type, slug,
+		)
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(expectedDoc.Slug),
+				PreConfig: func() {
+					docCommonGocks()
+					gock.New(testURL).Post("/docs").Times(1).Reply(201).JSON(expectedDoc)
+					gock.New(testURL).Get("/docs/" + expectedDoc.Slug).Times(4).Reply(200).JSON(expectedDoc)
+				},
+				Check: resource.TestCheckResourceAttr("readme_doc.test", "slug", expectedDoc.Slug),
+			},
+			// Changing the slug updates the doc in place.
+			{
+				Config: config(renamedDoc.Slug),
+				PreConfig: func() {
+					gock.OffAll()
+					docCommonGocks()
+					gock.New(testURL).Get("/docs/" + expectedDoc.Slug).Times(1).Reply(200).JSON(expectedDoc)
+					gock.New(testURL).Put("/docs/" + expectedDoc.Slug).
+						BodyString(`"slug":"renamed"`).
+						Times(1).Reply(200).JSON(renamedDoc)
+					gock.New(testURL).Get("/docs/" + renamedDoc.Slug).Times(3).Reply(200).JSON(renamedDoc)
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_doc.test", "id", expectedDoc.ID),
+					resource.TestCheckResourceAttr("readme_doc.test", "slug", renamedDoc.Slug),
+					resource.TestCheckResourceAttr("readme_doc.test", "previous_slug", expectedDoc.Slug),
+				),
+			},
+			// A slug changed in the web UI is found by the doc ID and shown as drift.
+			{
+				Config: config(renamedDoc.Slug),
+				PreConfig: func() {
+					gock.OffAll()
+					docCommonGocks()
+					mockAPIError.Error = "DOC_NOTFOUND"
+					gock.New(testURL).Get("/docs/" + renamedDoc.Slug).Times(1).Reply(404).JSON(mockAPIError)
+					gock.New(testURL).Post("/docs/search").Times(1).Reply(200).JSON(readme.DocSearchResults{
+						Results: []readme.DocSearchResult{{ReferenceID: expectedDoc.ID, Slug: expectedDoc.Slug}},
+					})
+					gock.New(testURL).Get("/docs/" + expectedDoc.Slug).Times(2).Reply(200).JSON(expectedDoc)
+					gock.New(testURL).Delete("/docs/" + expectedDoc.Slug).Times(1).Reply(204)
+				},
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// Test that the `slug` front matter key adopts an existing doc with the slug.
+func TestDocResource_FrontMatter_Slug_Adopt(t *testing.T) {
+	// Close all gocks after completion.
+	defer gock.OffAll()
+
+	expectedDoc := mockDoc
+
+	config := testProviderConfig + fmt.Sprintf(`
+		resource "readme_doc" "test" {
+			title    = "%s"
+			body     = "---\nslug: %s\n---\n%s"
+			category = "%s"
+			type     = "%s"
+		}`,
+		expectedDoc.Title, expectedDoc.Slug, expectedDoc.Body, expectedDoc.Category, expectedDoc.Type,
+	)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				PreConfig: func() {
+					docCommonGocks()
+					gock.New(testURL).Get("/docs/" + expectedDoc.Slug).Persist().Reply(200).JSON(expectedDoc)
+					gock.New(testURL).Put("/docs/" + expectedDoc.Slug).Times(1).Reply(200).JSON(expectedDoc)
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_doc.test", "id", expectedDoc.ID),
+					resource.TestCheckResourceAttr("readme_doc.test", "slug", expectedDoc.Slug),
+					resource.TestCheckResourceAttr("readme_doc.test", "use_slug", expectedDoc.Slug),
+				),
+			},
+			{
+				Config: config,
+				PreConfig: func() {
+					gock.New(testURL).Delete("/docs/" + expectedDoc.Slug).Times(1).Reply(204)
+				},
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestDocPlanToParams_WritableAttributes(t *testing.T) {
+	ctx := context.Background()
+
+	// Unknown and null optional attributes aren't sent.
+	params := docPlanToParams(ctx, docModel{
+		Excerpt:      types.StringUnknown(),
+		Icon:         types.StringNull(),
+		LinkExternal: types.BoolUnknown(),
+		Metadata:     types.ObjectUnknown(docModelMetadataValue(readme.DocMetadata{}).AttributeTypes(ctx)),
+	}, "")
+	if params.Excerpt != nil || params.Icon != nil || params.LinkExternal != nil || params.Metadata != nil {
+		t.Errorf("expected unknown and null attributes not to be sent, got %+v", params)
+	}
+
+	params = docPlanToParams(ctx, docModel{
+		Deprecated:   types.BoolValue(true),
+		Error:        docModelErrorValue(readme.DocErrorObject{Code: "400"}),
+		Excerpt:      types.StringValue("A summary."),
+		Icon:         types.StringValue("fa-book"),
+		LinkExternal: types.BoolValue(false),
+		LinkURL:      types.StringValue("https://example.com"),
+		Metadata: docModelMetadataValue(readme.DocMetadata{
+			Description: "An SEO description.",
+			Image:       []any{"https://example.com/example.png"},
+			Title:       "An SEO title",
+		}),
+	}, "")
+
+	if *params.Deprecated != true || *params.Excerpt != "A summary." || *params.Icon != "fa-book" ||
+		*params.LinkExternal != false || *params.LinkURL != "https://example.com" || params.Error.Code != "400" {
+		t.Errorf("unexpected params %+v", params)
+	}
+
+	expectMetadata := &docMetadataParams{
+		Description: "An SEO description.",
+		Image:       []string{"https://example.com/example.png"},
+		Title:       "An SEO title",
+	}
+	if !reflect.DeepEqual(params.Metadata, expectMetadata) {
+		t.Errorf("expected metadata %+v, got %+v", expectMetadata, params.Metadata)
+	}
+
+	// Null metadata is sent empty to clear it.
+	params = docPlanToParams(ctx, docModel{Metadata: docModelMetadataValue(readme.DocMetadata{})}, "")
+	if !reflect.DeepEqual(params.Metadata, &docMetadataParams{Image: []string{}}) {
+		t.Errorf("expected empty metadata, got %+v", params.Metadata)
+	}
+}
+
+func TestDocPlanRemoved(t *testing.T) {
+	ctx := context.Background()
+	body := markdown.NewValue(strings.TrimSpace(removeIndents(`
+		---
+		excerpt: A summary.
+		---
+		This is a document.`)))
+
+	// The values kept from the state.
+	plan := docModel{
+		Deprecated:   types.BoolValue(true),
+		Excerpt:      types.StringValue("A summary."),
+		Icon:         types.StringValue("fa-book"),
+		LinkExternal: types.BoolValue(true),
+		LinkURL:      types.StringValue("https://example.com"),
+		Metadata:     docModelMetadataValue(readme.DocMetadata{Title: "An SEO title"}),
+	}
+
+	config := docModel{
+		Body:         body,
+		BodyFile:     types.StringNull(),
+		Deprecated:   types.BoolNull(),
+		Excerpt:      types.StringNull(),
+		Icon:         types.StringValue("fa-book"),
+		LinkExternal: types.BoolNull(),
+		LinkURL:      types.StringNull(),
+		Metadata:     types.ObjectNull(plan.Metadata.AttributeTypes(ctx)),
+	}
+
+	// Every attribute was set when the doc was last saved, except `link_external`.
+	previous := map[string]bool{
+		"deprecated": true,
+		"excerpt":    true,
+		"icon":       true,
+		"link_url":   true,
+		"metadata":   true,
+	}
+
+	docPlanRemoved(ctx, config, &plan, previous)
+
+	if plan.Deprecated.ValueBool() || plan.LinkURL.ValueString() != "" || !plan.Metadata.IsNull() {
+		t.Errorf("expected the removed attributes to be cleared, got %+v", plan)
+	}
+	if !plan.LinkExternal.ValueBool() {
+		t.Error("expected the attribute that was never set to be kept")
+	}
+	if plan.Excerpt.ValueString() != "A summary." {
+		t.Errorf("expected the front matter excerpt to be kept, got %s", plan.Excerpt)
+	}
+	if plan.Icon.ValueString() != "fa-book" {
+		t.Errorf("expected the configured icon to be kept, got %s", plan.Icon)
+	}
+
+	// Nothing is cleared until the body is known.
+	plan.LinkURL = types.StringValue("https://example.com")
+	config.Body = markdown.NewUnknownValue()
+	docPlanRemoved(ctx, config, &plan, previous)
+	if plan.LinkURL.ValueString() != "https://example.com" {
+		t.Errorf("expected the attributes to be kept when the body is unknown, got %s", plan.LinkURL)
+	}
+
+	// The configured attributes are recorded for the next plan.
+	config.Body = body
+	var saved []byte
+	diags := docSetConfigured(ctx, config, func(_ context.Context, key string, value []byte) diag.Diagnostics {
+		if key != docConfiguredKey {
+			t.Errorf("unexpected private state key %s", key)
+		}
+		saved = value
+
+		return nil
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expect := `{"deprecated":false,"excerpt":true,"icon":true,"link_external":false,"link_url":false,"metadata":false}`
+	if string(saved) != expect {
+		t.Errorf("expected the configured attributes %s, got %s", expect, saved)
+	}
+}
+
+func TestDocFrontMatterObjects(t *testing.T) {
+	ctx := context.Background()
+	body := markdown.NewValue(strings.TrimSpace(removeIndents(`
+		---
+		error:
+		  code: "404"
+		metadata:
+		  title: An SEO title
+		  description: An SEO description.
+		  image:
+		    - https://example.com/example.png
+		---
+		This is a document.`)))
+
+	plan := docModel{}
+	docFrontMatterObjects(ctx, docModel{Body: body}, &plan)
+
+	expectError := docModelErrorValue(readme.DocErrorObject{Code: "404"})
+	if !plan.Error.Equal(expectError) {
+		t.Errorf("expected the error %s, got %s", expectError, plan.Error)
+	}
+
+	expectMetadata := docModelMetadataValue(readme.DocMetadata{
+		Description: "An SEO description.",
+		Image:       []any{"https://example.com/example.png"},
+		Title:       "An SEO title",
+	})
+	if !plan.Metadata.Equal(expectMetadata) {
+		t.Errorf("expected the metadata %s, got %s", expectMetadata, plan.Metadata)
+	}
+
+	// Configured attributes take precedence over front matter.
+	configured := docModelMetadataValue(readme.DocMetadata{Title: "Configured"})
+	plan = docModel{Metadata: configured}
+	docFrontMatterObjects(ctx, docModel{Body: body, Metadata: configured}, &plan)
+	if !plan.Metadata.Equal(configured) {
+		t.Errorf("expected the configured metadata, got %s", plan.Metadata)
+	}
+}
@@ -117,7 +117,12 @@ func (d *docSearchDataSource) Read(
 	// Get doc metadata from ReadMe API
 	doc, apiResponse, err := d.client.Doc.Search(state.Query.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to retrieve doc metadata.", clientError(err, apiResponse))
+		resp.Diagnostics.Append(clientDiagnostic(
+			"Unable to retrieve doc metadata.",
+			err,
+			apiResponse,
+			nil,
+		))
 
 		return
 	}
@@ -204,7 +209,7 @@ func (d *docSearchDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
 
 // Schema for the readme_doc_search data source.
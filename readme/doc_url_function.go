@@ -0,0 +1,103 @@
+package readme
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &docURLFunction{}
+
+// docURLFunction is the function implementation.
+type docURLFunction struct{}
+
+// NewDocURLFunction is a helper function to simplify the provider implementation.
+func NewDocURLFunction() function.Function {
+	return &docURLFunction{}
+}
+
+// Metadata returns the function name.
+func (f *docURLFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "doc_url"
+}
+
+// Definition defines the parameters and return type for the function.
+func (f *docURLFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Build the URL of a doc.",
+		Description: "Returns the URL of a doc in the ReadMe hub, such as " +
+			"`https://docs.example.com/v1.1/docs/getting-started`.\n\n" +
+			"The version is omitted from the URL when it's null or empty, which links to the doc in " +
+			"the project's stable version. The base URL is the `base_url` attribute of the " +
+			"`readme_project` data source.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base_url",
+				Description: "The base URL of the project.",
+			},
+			function.StringParameter{
+				Name:           "version",
+				Description:    "The version of the doc.",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:        "slug",
+				Description: "The slug of the doc.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the doc URL.
+func (f *docURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var baseURL, slug string
+	var version types.String
+	resp.Error = req.Arguments.Get(ctx, &baseURL, &version, &slug)
+	if resp.Error != nil {
+		return
+	}
+
+	if strings.TrimSpace(slug) == "" {
+		resp.Error = function.NewArgumentFuncError(2, "The slug must not be empty.")
+
+		return
+	}
+
+	docURL, err := buildDocURL(baseURL, version.ValueString(), slug)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, docURL)
+}
+
+// buildDocURL returns the hub URL of a doc, including the version if it's set.
+func buildDocURL(baseURL, version, slug string) (string, error) {
+	base, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return "", fmt.Errorf("the base URL '%s' must be an absolute URL", baseURL)
+	}
+
+	docPath := "/docs/" + url.PathEscape(slug)
+	if version = cleanVersion(version); version != "" {
+		docPath = "/v" + url.PathEscape(version) + docPath
+	}
+
+	return strings.TrimRight(base.String(), "/") + docPath, nil
+}
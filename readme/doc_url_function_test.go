@@ -0,0 +1,75 @@
+package readme
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDocURLFunction(t *testing.T) {
+	tests := map[string]struct {
+		baseURL     string
+		version     types.String
+		slug        string
+		expectURL   string
+		expectError bool
+	}{
+		"versioned": {
+			baseURL:   "https://docs.example.com",
+			version:   types.StringValue("v1.1"),
+			slug:      "getting-started",
+			expectURL: "https://docs.example.com/v1.1/docs/getting-started",
+		},
+		"null version": {
+			baseURL:   "https://docs.example.com/",
+			version:   types.StringNull(),
+			slug:      "getting-started",
+			expectURL: "https://docs.example.com/docs/getting-started",
+		},
+		"empty version": {
+			baseURL:   "http://terraform-test.readme.io",
+			version:   types.StringValue(""),
+			slug:      "a b",
+			expectURL: "http://terraform-test.readme.io/docs/a%20b",
+		},
+		"relative base URL": {
+			baseURL:     "docs.example.com",
+			version:     types.StringNull(),
+			slug:        "getting-started",
+			expectError: true,
+		},
+		"empty slug": {
+			baseURL:     "https://docs.example.com",
+			version:     types.StringNull(),
+			slug:        "",
+			expectError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := runFunction(
+				NewDocURLFunction(),
+				types.StringUnknown(),
+				types.StringValue(tc.baseURL),
+				tc.version,
+				types.StringValue(tc.slug),
+			)
+
+			if tc.expectError {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tc.expectURL)) {
+				t.Errorf("expected '%s', got %s", tc.expectURL, got)
+			}
+		})
+	}
+}
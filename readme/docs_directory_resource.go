@@ -0,0 +1,956 @@
+package readme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	fm "github.com/adrg/frontmatter"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/frontmatter"
+)
+
+const (
+	// docsDirectoryDefaultOrder is the order ReadMe uses when a doc doesn't specify one.
+	docsDirectoryDefaultOrder = 999
+
+	// docsDirectoryDefaultType is the doc type used when a file doesn't specify one.
+	docsDirectoryDefaultType = "basic"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &docsDirectoryResource{}
+	_ resource.ResourceWithConfigure   = &docsDirectoryResource{}
+	_ resource.ResourceWithModifyPlan  = &docsDirectoryResource{}
+	_ resource.ResourceWithImportState = &docsDirectoryResource{}
+)
+
+// docsDirectoryResource is the resource implementation.
+type docsDirectoryResource struct {
+	client  *readme.Client
+	config  providerConfig
+	locks   *categoryLocks
+	lookups *lookupCache
+}
+
+// docsDirectoryModel is the resource model.
+type docsDirectoryModel struct {
+	ID           types.String `tfsdk:"id"`
+	Path         types.String `tfsdk:"path"`
+	Version      types.String `tfsdk:"version"`
+	CategorySlug types.String `tfsdk:"category_slug"`
+	Docs         types.Map    `tfsdk:"docs"`
+}
+
+// docsDirectoryDocModel is a doc synced from a file in the directory.
+type docsDirectoryDocModel struct {
+	ID            types.String `tfsdk:"id"`
+	Slug          types.String `tfsdk:"slug"`
+	Title         types.String `tfsdk:"title"`
+	Category      types.String `tfsdk:"category"`
+	CategorySlug  types.String `tfsdk:"category_slug"`
+	ParentDoc     types.String `tfsdk:"parent_doc"`
+	ParentDocSlug types.String `tfsdk:"parent_doc_slug"`
+	Order         types.Int64  `tfsdk:"order"`
+	Hidden        types.Bool   `tfsdk:"hidden"`
+	Type          types.String `tfsdk:"type"`
+	Hash          types.String `tfsdk:"hash"`
+}
+
+// docsDirectoryDocAttrTypes are the attribute types of a doc in the `docs` attribute.
+var docsDirectoryDocAttrTypes = map[string]attr.Type{
+	"id":              types.StringType,
+	"slug":            types.StringType,
+	"title":           types.StringType,
+	"category":        types.StringType,
+	"category_slug":   types.StringType,
+	"parent_doc":      types.StringType,
+	"parent_doc_slug": types.StringType,
+	"order":           types.Int64Type,
+	"hidden":          types.BoolType,
+	"type":            types.StringType,
+	"hash":            types.StringType,
+}
+
+// docsDirectoryFile is a Markdown file in the directory.
+type docsDirectoryFile struct {
+	matter frontmatter.ReadmeFrontMatter
+	body   string
+	hash   string
+	// slugSet is true when the front matter sets the slug rather than it coming from the file name.
+	slugSet bool
+}
+
+// NewDocsDirectoryResource is a helper function to simplify the provider implementation.
+func NewDocsDirectoryResource() resource.Resource {
+	return &docsDirectoryResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *docsDirectoryResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_docs_directory"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *docsDirectoryResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	_ *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg := req.ProviderData.(*providerData)
+	r.client = cfg.client
+	r.config = cfg.config
+	r.locks = cfg.locks
+	r.lookups = cfg.lookups
+}
+
+// Schema defines the schema for the resource.
+func (r *docsDirectoryResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manage the docs for a directory of Markdown files on ReadMe.com\n\n" +
+			"Each `.md` or `.markdown` file in the directory and its subdirectories is synced to a doc, " +
+			"similar to the `rdme docs` command. The doc attributes are read from the file's front " +
+			"matter: `title` is required and `category` or `categorySlug` is required unless " +
+			"`category_slug` is set. `parentDoc`, `parentDocSlug`, `order`, `hidden`, `type`, and " +
+			"`slug` are optional.\n\n" +
+			"A doc is only sent to ReadMe when its file or front matter changes. Parent docs are " +
+			"created before their children and children are deleted before their parents.\n\n" +
+			"New docs are matched to existing docs in ReadMe by the front matter `slug`, or the file " +
+			"name when it isn't set. New docs are created with that slug, and a doc is renamed when its " +
+			"front matter `slug` changes. Managed docs are tracked by their IDs, so a doc renamed in the " +
+			"web UI keeps its new slug unless the front matter `slug` is set.\n\n" +
+			"See <https://docs.readme.com/main/reference/createdoc> for more information about this API " +
+			"endpoint.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The path of the directory.",
+				Computed:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "The path of the directory of Markdown files.",
+				Required:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "The version to create the docs under. Defaults to the provider's " +
+					"`config.default_version` if set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"category_slug": schema.StringAttribute{
+				Description: "The category slug for files that don't set `category` or `categorySlug` " +
+					"in their front matter. Defaults to the provider's `config.default_category_slug` if set.",
+				Optional: true,
+			},
+			"docs": schema.MapNestedAttribute{
+				Description: "The docs synced from the directory, keyed by the file path relative to " +
+					"the directory.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The ID of the doc.",
+							Computed:    true,
+						},
+						"slug": schema.StringAttribute{
+							Description: "The slug of the doc.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "The title of the doc.",
+							Computed:    true,
+						},
+						"category": schema.StringAttribute{
+							Description: "The category ID of the doc.",
+							Computed:    true,
+						},
+						"category_slug": schema.StringAttribute{
+							Description: "The category slug of the doc.",
+							Computed:    true,
+						},
+						"parent_doc": schema.StringAttribute{
+							Description: "The parent doc ID of the doc.",
+							Computed:    true,
+						},
+						"parent_doc_slug": schema.StringAttribute{
+							Description: "The parent doc slug of the doc.",
+							Computed:    true,
+						},
+						"order": schema.Int64Attribute{
+							Description: "The position of the doc in the project sidebar. Defaults to 999.",
+							Computed:    true,
+						},
+						"hidden": schema.BoolAttribute{
+							Description: "Whether the doc is hidden. Defaults to false.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The type of the doc. Defaults to `basic`.",
+							Computed:    true,
+						},
+						"hash": schema.StringAttribute{
+							Description: "The SHA-256 hash of the file.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan reads the directory and plans the docs for each file.
+//
+// Docs for unchanged files keep their state, so the plan shows which files are created, updated,
+// or deleted.
+func (r *docsDirectoryResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Skip when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config docsDirectoryModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	prior := map[string]docsDirectoryDocModel{}
+	if !req.State.Raw.IsNull() {
+		var state docsDirectoryModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &prior, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Use the provider's default version if it's not set.
+	if config.Version.IsNull() {
+		plan.Version = types.StringNull()
+		if r.config.DefaultVersion.ValueString() != "" {
+			plan.Version = r.config.DefaultVersion
+		}
+	}
+
+	plan.ID = plan.Path
+
+	if plan.Path.IsUnknown() || plan.CategorySlug.IsUnknown() {
+		plan.Docs = types.MapUnknown(types.ObjectType{AttrTypes: docsDirectoryDocAttrTypes})
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+
+		return
+	}
+
+	files, err := readDocsDirectory(plan.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Unable to read docs directory.", err.Error())
+
+		return
+	}
+
+	planned := map[string]docsDirectoryDocModel{}
+	for file, content := range files {
+		doc := content.model(r.defaultCategorySlug(plan))
+		if detail := doc.validate(); detail != "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("path"),
+				"Invalid doc front matter.",
+				fmt.Sprintf("The file %s is invalid: %s", file, detail),
+			)
+
+			continue
+		}
+
+		doc.ID = types.StringUnknown()
+		doc.Slug = types.StringUnknown()
+
+		action := "create"
+		if existing, ok := prior[file]; ok {
+			action = "no changes"
+			if existing.changed(doc) || content.renames(existing.Slug.ValueString()) {
+				action = "update"
+			}
+
+			// The slug only changes when the title or the front matter slug changes.
+			doc.ID = existing.ID
+			if existing.Title.Equal(doc.Title) && !content.renames(existing.Slug.ValueString()) {
+				doc.Slug = existing.Slug
+			}
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("docs directory file %s: %s", file, action))
+		planned[file] = doc
+	}
+
+	for file := range prior {
+		if _, ok := planned[file]; !ok {
+			tflog.Info(ctx, fmt.Sprintf("docs directory file %s: delete", file))
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	docs, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: docsDirectoryDocAttrTypes}, planned)
+	resp.Diagnostics.Append(diags...)
+	plan.Docs = docs
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// Create creates the docs and sets the initial Terraform state.
+func (r *docsDirectoryResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "docs directory") {
+		return
+	}
+
+	var plan docsDirectoryModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.sync(ctx, plan, nil, &resp.State, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data.
+//
+// Docs that no longer exist in ReadMe are removed from the state so they're created again.
+func (r *docsDirectoryResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state docsDirectoryModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	docs := map[string]docsDirectoryDocModel{}
+	resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &docs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestOpts := apiRequestOptions(state.Version)
+
+	for file, doc := range docs {
+		response, found, apiResponse, err := r.readDoc(ctx, doc, requestOpts)
+		if err != nil {
+			resp.Diagnostics.Append(clientDiagnostic(
+				fmt.Sprintf("Unable to retrieve doc for file %s.", file),
+				err,
+				apiResponse,
+				apiErrorPaths{"DOC_NOTFOUND": path.Empty()},
+			))
+
+			return
+		}
+
+		if !found {
+			tflog.Info(ctx, fmt.Sprintf("doc %s for file %s not found, removing from state", doc.Slug, file))
+			delete(docs, file)
+
+			continue
+		}
+
+		// The slug may have been changed in the web UI.
+		doc.ID = types.StringValue(response.ID)
+		doc.Slug = types.StringValue(response.Slug)
+		docs[file] = doc
+	}
+
+	state.Docs = docsDirectoryDocsValue(ctx, docs, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// readDoc retrieves the doc of a file. The doc is tracked by its ID since its slug can be changed
+// in the web UI, so it's searched for by its ID when one is stored. Hidden docs can't be found by
+// searching, so the doc with the stored slug is used if it has the same ID. Otherwise, the doc is
+// retrieved by its slug.
+//
+// False is returned if the doc doesn't exist.
+func (r *docsDirectoryResource) readDoc(
+	ctx context.Context,
+	doc docsDirectoryDocModel,
+	options readme.RequestOptions,
+) (readme.Doc, bool, *readme.APIResponse, error) {
+	id := doc.ID.ValueString()
+	if id != "" {
+		response, apiResponse, err := r.client.Doc.Get(IDPrefix+id, options)
+		switch {
+		case err == nil:
+			return response, true, apiResponse, nil
+		case apiResponse != nil && apiResponse.HTTPResponse.StatusCode == 404:
+			return readme.Doc{}, false, apiResponse, nil
+		case !strings.Contains(err.Error(), "no doc found matching id"):
+			return readme.Doc{}, false, apiResponse, err //nolint:wrapcheck // The error is formatted by the caller.
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("doc %s not found when searching by ID, retrieving it by slug %s", id, doc.Slug))
+	}
+
+	response, apiResponse, err := r.client.Doc.Get(doc.Slug.ValueString(), options)
+	if err != nil {
+		if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == 404 {
+			return readme.Doc{}, false, apiResponse, nil
+		}
+
+		return readme.Doc{}, false, apiResponse, err //nolint:wrapcheck // The error is formatted by the caller.
+	}
+
+	// The slug belongs to another doc if the doc was renamed and another doc took its slug.
+	if id != "" && response.ID != id {
+		tflog.Info(ctx, fmt.Sprintf("doc %s has a different ID than %s", doc.Slug, id))
+
+		return readme.Doc{}, false, apiResponse, nil
+	}
+
+	return response, true, apiResponse, nil
+}
+
+// Update syncs the docs for changed files and sets the updated Terraform state on success.
+func (r *docsDirectoryResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "update", "docs directory") {
+		return
+	}
+
+	var plan, state docsDirectoryModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	prior := map[string]docsDirectoryDocModel{}
+	resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.sync(ctx, plan, prior, &resp.State, &resp.Diagnostics)
+}
+
+// Delete deletes the docs and removes the Terraform state on success.
+func (r *docsDirectoryResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	if r.config.readOnly(&resp.Diagnostics, "delete", "docs directory") {
+		return
+	}
+
+	var state docsDirectoryModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	docs := map[string]docsDirectoryDocModel{}
+	resp.Diagnostics.Append(state.Docs.ElementsAs(ctx, &docs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := r.locks.lock(ctx, docsDirectoryLockKeys(state.Version, docs)...)
+	defer unlock()
+
+	// Delete children before their parents.
+	order := docsDirectoryOrder(docs, nil)
+	for i := len(order) - 1; i >= 0; i-- {
+		if !r.deleteDoc(ctx, order[i], docs[order[i]], state.Version, &resp.Diagnostics) {
+			return
+		}
+	}
+}
+
+// ImportState imports the docs directory by its path. The docs are created or adopted on the
+// next apply.
+func (r *docsDirectoryResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx,
+		path.Root("docs"),
+		types.MapValueMust(types.ObjectType{AttrTypes: docsDirectoryDocAttrTypes}, map[string]attr.Value{}),
+	)...)
+}
+
+// sync deletes the docs for removed files and creates or updates the docs for new and changed
+// files. The state is saved with the docs that were synced, even if an error occurs.
+func (r *docsDirectoryResource) sync(
+	ctx context.Context,
+	plan docsDirectoryModel,
+	prior map[string]docsDirectoryDocModel,
+	state stateSetter,
+	diags *diag.Diagnostics,
+) {
+	planned := map[string]docsDirectoryDocModel{}
+	diags.Append(plan.Docs.ElementsAs(ctx, &planned, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	files, err := readDocsDirectory(plan.Path.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("path"), "Unable to read docs directory.", err.Error())
+
+		return
+	}
+
+	// The synced docs begin with the prior docs since they exist until they're deleted.
+	synced := make(map[string]docsDirectoryDocModel, len(prior))
+	for file, doc := range prior {
+		synced[file] = doc
+	}
+
+	defer func() {
+		plan.Docs = docsDirectoryDocsValue(ctx, synced, diags)
+		diags.Append(state.Set(ctx, plan)...)
+	}()
+
+	unlock := r.locks.lock(ctx, append(
+		docsDirectoryLockKeys(plan.Version, prior),
+		docsDirectoryLockKeys(plan.Version, planned)...,
+	)...)
+	defer unlock()
+	defer r.lookups.invalidate(lookupDoc)
+
+	// Delete the docs for removed files, children before their parents.
+	removed := map[string]docsDirectoryDocModel{}
+	for file, doc := range prior {
+		if _, ok := planned[file]; !ok {
+			removed[file] = doc
+		}
+	}
+
+	order := docsDirectoryOrder(removed, nil)
+	for i := len(order) - 1; i >= 0; i-- {
+		if !r.deleteDoc(ctx, order[i], removed[order[i]], plan.Version, diags) {
+			return
+		}
+		delete(synced, order[i])
+	}
+
+	// Create or update the docs for new and changed files, parents before their children. The
+	// slugs of synced docs are tracked by the slug from their file to resolve the parent of a child doc.
+	slugs := map[string]string{}
+	for _, file := range docsDirectoryOrder(planned, files) {
+		doc := planned[file]
+		content, ok := files[file]
+		if !ok || content.hash != doc.Hash.ValueString() {
+			diags.AddAttributeError(
+				path.Root("path"),
+				"Docs directory changed.",
+				fmt.Sprintf("The file %s changed after the plan was created. Run the plan again.", file),
+			)
+
+			return
+		}
+
+		if existing, ok := prior[file]; ok && !existing.changed(doc) && !content.renames(existing.Slug.ValueString()) {
+			slugs[content.matter.Slug] = existing.Slug.ValueString()
+
+			continue
+		}
+
+		params := content.params(doc)
+		if parent, ok := slugs[params.ParentDocSlug]; ok {
+			params.ParentDocSlug = parent
+		}
+
+		response, ok := r.saveDoc(ctx, file, content, params, prior[file], plan.Version, diags)
+		if !ok {
+			return
+		}
+
+		doc.ID = types.StringValue(response.ID)
+		doc.Slug = types.StringValue(response.Slug)
+		synced[file] = doc
+		slugs[content.matter.Slug] = response.Slug
+	}
+}
+
+// saveDoc updates the doc for a file if it exists, otherwise it creates it.
+//
+// The doc is updated if it's in the prior state or a doc matches the file's slug.
+func (r *docsDirectoryResource) saveDoc(
+	ctx context.Context,
+	file string,
+	content docsDirectoryFile,
+	params readme.DocParams,
+	existing docsDirectoryDocModel,
+	version types.String,
+	diags *diag.Diagnostics,
+) (readme.Doc, bool) {
+	requestOpts := apiRequestOptions(version)
+
+	slug := existing.Slug.ValueString()
+	if slug == "" {
+		slug = content.matter.Slug
+		if _, apiResponse, err := r.client.Doc.Get(slug, requestOpts); err != nil {
+			if apiResponse == nil || apiResponse.HTTPResponse.StatusCode != 404 {
+				diags.Append(clientDiagnostic(
+					fmt.Sprintf("Unable to check if a doc exists for file %s.", file),
+					err,
+					apiResponse,
+					apiErrorPaths{"DOC_NOTFOUND": path.Empty()},
+				))
+
+				return readme.Doc{}, false
+			}
+
+			slug = ""
+		}
+	}
+
+	// New docs are created with the slug from the file, and existing docs are only renamed when
+	// the front matter sets the slug.
+	newSlug := content.matter.Slug
+	if slug != "" && !content.renames(slug) {
+		newSlug = slug
+	}
+
+	if slug != "" {
+		tflog.Info(ctx, fmt.Sprintf("updating doc %s for file %s", slug, file))
+	} else {
+		tflog.Info(ctx, fmt.Sprintf("creating doc %s for file %s", newSlug, file))
+	}
+
+	response, apiResponse, err := saveDoc(r.client, slug, newSlug, docWriteParams{DocParams: params}, requestOpts)
+	if err != nil {
+		diags.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to save doc for file %s.", file),
+			err,
+			apiResponse,
+			apiErrorPaths{
+				"DOC_NOTFOUND":      path.Empty(),
+				"CATEGORY_NOTFOUND": path.Root("category_slug"),
+				"VERSION_NOTFOUND":  path.Root("version"),
+			},
+		))
+
+		return readme.Doc{}, false
+	}
+
+	return response, true
+}
+
+// deleteDoc deletes the doc for a file. A doc that doesn't exist is ignored.
+func (r *docsDirectoryResource) deleteDoc(
+	ctx context.Context,
+	file string,
+	doc docsDirectoryDocModel,
+	version types.String,
+	diags *diag.Diagnostics,
+) bool {
+	tflog.Info(ctx, fmt.Sprintf("deleting doc %s for file %s", doc.Slug, file))
+
+	_, apiResponse, err := r.client.Doc.Delete(doc.Slug.ValueString(), apiRequestOptions(version))
+	if err != nil {
+		if apiResponse != nil && apiResponse.HTTPResponse.StatusCode == 404 {
+			return true
+		}
+
+		diags.Append(clientDiagnostic(
+			fmt.Sprintf("Unable to delete doc for file %s.", file),
+			err,
+			apiResponse,
+			apiErrorPaths{"DOC_NOTFOUND": path.Empty()},
+		))
+
+		return false
+	}
+
+	return true
+}
+
+// defaultCategorySlug returns the category slug for files that don't set a category.
+func (r *docsDirectoryResource) defaultCategorySlug(plan docsDirectoryModel) string {
+	if plan.CategorySlug.ValueString() != "" {
+		return plan.CategorySlug.ValueString()
+	}
+
+	return r.config.DefaultCategorySlug.ValueString()
+}
+
+// stateSetter is implemented by the Terraform state to save a model.
+type stateSetter interface {
+	Set(ctx context.Context, val interface{}) diag.Diagnostics
+}
+
+// readDocsDirectory returns the Markdown files in a directory and its subdirectories, keyed by the
+// path relative to the directory. Hidden files and directories are skipped.
+func readDocsDirectory(dir string) (map[string]docsDirectoryFile, error) {
+	files := map[string]docsDirectoryFile{}
+
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if file != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(file))
+		if entry.IsDir() || (ext != ".md" && ext != ".markdown") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return fmt.Errorf("unable to determine the relative path of %s: %w", file, err)
+		}
+
+		content, err := readDocsDirectoryFile(file)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(rel)] = content
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", dir, err)
+	}
+
+	return files, nil
+}
+
+// readDocsDirectoryFile reads and parses a Markdown file.
+func readDocsDirectoryFile(file string) (docsDirectoryFile, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return docsDirectoryFile{}, fmt.Errorf("unable to read %s: %w", file, err)
+	}
+
+	content := docsDirectoryFile{}
+	body, err := fm.Parse(strings.NewReader(string(data)), &content.matter)
+	if err != nil {
+		return docsDirectoryFile{}, fmt.Errorf("unable to parse the front matter of %s: %w", file, err)
+	}
+
+	sum := sha256.Sum256(data)
+	content.body = strings.TrimPrefix(string(body), "\n")
+	content.hash = hex.EncodeToString(sum[:])
+
+	// The file name is used as the slug if the front matter doesn't set it, matching rdme.
+	content.slugSet = content.matter.Slug != ""
+	if !content.slugSet {
+		content.matter.Slug = slugify(strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+	}
+
+	return content, nil
+}
+
+// model returns the planned doc for the file. The ID and slug are set by the caller.
+func (f docsDirectoryFile) model(defaultCategorySlug string) docsDirectoryDocModel {
+	doc := docsDirectoryDocModel{
+		Title:         types.StringValue(f.matter.Title),
+		Category:      stringOrNull(f.matter.Category),
+		CategorySlug:  stringOrNull(f.matter.CategorySlug),
+		ParentDoc:     stringOrNull(f.matter.ParentDoc),
+		ParentDocSlug: stringOrNull(f.matter.ParentDocSlug),
+		Order:         types.Int64Value(docsDirectoryDefaultOrder),
+		Hidden:        types.BoolValue(false),
+		Type:          types.StringValue(docsDirectoryDefaultType),
+		Hash:          types.StringValue(f.hash),
+	}
+
+	if f.matter.Category == "" && f.matter.CategorySlug == "" {
+		doc.CategorySlug = stringOrNull(defaultCategorySlug)
+	}
+	if f.matter.Order != 0 {
+		doc.Order = types.Int64Value(f.matter.Order)
+	}
+	if f.matter.Hidden != nil {
+		doc.Hidden = types.BoolValue(*f.matter.Hidden)
+	}
+	if f.matter.Type != "" {
+		doc.Type = types.StringValue(f.matter.Type)
+	}
+
+	return doc
+}
+
+// renames returns true if the front matter sets a slug that differs from the doc's current slug.
+func (f docsDirectoryFile) renames(slug string) bool {
+	return f.slugSet && slug != "" && f.matter.Slug != slug
+}
+
+// params returns the parameters to create or update the doc for the file.
+func (f docsDirectoryFile) params(doc docsDirectoryDocModel) readme.DocParams {
+	return readme.DocParams{
+		Body:          f.body,
+		Category:      doc.Category.ValueString(),
+		CategorySlug:  doc.CategorySlug.ValueString(),
+		Error:         f.matter.Error,
+		Hidden:        doc.Hidden.ValueBoolPointer(),
+		Order:         intPoint(int(doc.Order.ValueInt64())),
+		ParentDoc:     doc.ParentDoc.ValueString(),
+		ParentDocSlug: doc.ParentDocSlug.ValueString(),
+		Title:         doc.Title.ValueString(),
+		Type:          doc.Type.ValueString(),
+	}
+}
+
+// validate returns a description of the problem if the doc is missing required front matter.
+func (d docsDirectoryDocModel) validate() string {
+	if d.Title.ValueString() == "" {
+		return "the front matter must set `title`."
+	}
+
+	if d.Category.ValueString() == "" && d.CategorySlug.ValueString() == "" {
+		return "the front matter must set `category` or `categorySlug`, or the resource's " +
+			"`category_slug` or the provider's `config.default_category_slug` must be set."
+	}
+
+	return ""
+}
+
+// changed determines if the planned doc differs from the doc in the state.
+func (d docsDirectoryDocModel) changed(plan docsDirectoryDocModel) bool {
+	return !d.Hash.Equal(plan.Hash) ||
+		!d.Title.Equal(plan.Title) ||
+		!d.Category.Equal(plan.Category) ||
+		!d.CategorySlug.Equal(plan.CategorySlug) ||
+		!d.ParentDoc.Equal(plan.ParentDoc) ||
+		!d.ParentDocSlug.Equal(plan.ParentDocSlug) ||
+		!d.Order.Equal(plan.Order) ||
+		!d.Hidden.Equal(plan.Hidden) ||
+		!d.Type.Equal(plan.Type)
+}
+
+// docsDirectoryOrder returns the files of the docs ordered with parents before their children.
+//
+// A doc is a child of another doc in the directory when its `parent_doc_slug` matches the other
+// doc's known slug, or the slug from its file if `files` is set.
+func docsDirectoryOrder(
+	docs map[string]docsDirectoryDocModel,
+	files map[string]docsDirectoryFile,
+) []string {
+	bySlug := map[string]string{}
+	for file, doc := range docs {
+		if content, ok := files[file]; ok && content.matter.Slug != "" {
+			bySlug[content.matter.Slug] = file
+		}
+		if slug := doc.Slug.ValueString(); slug != "" {
+			bySlug[slug] = file
+		}
+	}
+
+	depths := map[string]int{}
+	var depth func(file string, seen int) int
+	depth = func(file string, seen int) int {
+		if d, ok := depths[file]; ok {
+			return d
+		}
+
+		d := 0
+		parent, ok := bySlug[docs[file].ParentDocSlug.ValueString()]
+		// Stop at cycles, which ReadMe rejects when the doc is saved.
+		if ok && parent != file && seen < len(docs) {
+			d = depth(parent, seen+1) + 1
+		}
+		depths[file] = d
+
+		return d
+	}
+
+	order := make([]string, 0, len(docs))
+	for file := range docs {
+		depth(file, 0)
+		order = append(order, file)
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		if depths[order[i]] != depths[order[j]] {
+			return depths[order[i]] < depths[order[j]]
+		}
+
+		return order[i] < order[j]
+	})
+
+	return order
+}
+
+// docsDirectoryLockKeys returns the category lock keys for the docs.
+func docsDirectoryLockKeys(version types.String, docs map[string]docsDirectoryDocModel) []string {
+	keys := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		category := doc.CategorySlug.ValueString()
+		if category == "" && doc.Category.ValueString() != "" {
+			category = IDPrefix + doc.Category.ValueString()
+		}
+		keys = append(keys, categoryLockKey(version.ValueString(), category))
+	}
+
+	return keys
+}
+
+// docsDirectoryDocsValue returns the `docs` attribute value for the docs.
+func docsDirectoryDocsValue(
+	ctx context.Context,
+	docs map[string]docsDirectoryDocModel,
+	diags *diag.Diagnostics,
+) types.Map {
+	value, d := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: docsDirectoryDocAttrTypes}, docs)
+	diags.Append(d...)
+
+	return value
+}
+
+// stringOrNull returns a null string value for an empty string.
+func stringOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
@@ -0,0 +1,315 @@
+package readme
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// writeDocsDirectory writes files to a temporary directory and returns its path.
+func writeDocsDirectory(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestReadDocsDirectory(t *testing.T) {
+	dir := writeDocsDirectory(t, map[string]string{
+		"Getting Started.md":          "---\ntitle: Getting Started\ncategorySlug: guides\nhidden: true\n---\n\n# Welcome\n",
+		"guides/install.markdown":     "---\ntitle: Install\nslug: installation\nparentDocSlug: getting-started\n---\nSteps\n",
+		"guides/notes.txt":            "not markdown",
+		".drafts/draft.md":            "---\ntitle: Draft\n---\n",
+		"guides/.hidden.md":           "---\ntitle: Hidden\n---\n",
+		"reference/no-frontmatter.md": "# No front matter\n",
+	})
+
+	files, err := readDocsDirectory(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %d: %v", len(files), files)
+	}
+
+	started := files["Getting Started.md"]
+	if started.matter.Slug != "getting-started" {
+		t.Errorf("expected the slug from the file name, got '%s'", started.matter.Slug)
+	}
+	if started.body != "# Welcome\n" {
+		t.Errorf("expected the body without front matter, got '%s'", started.body)
+	}
+	if len(started.hash) != 64 {
+		t.Errorf("expected a SHA-256 hash, got '%s'", started.hash)
+	}
+
+	doc := started.model("")
+	if !doc.Hidden.ValueBool() || doc.Order.ValueInt64() != docsDirectoryDefaultOrder ||
+		doc.Type.ValueString() != docsDirectoryDefaultType || doc.CategorySlug.ValueString() != "guides" {
+		t.Errorf("unexpected doc: %+v", doc)
+	}
+
+	install := files["guides/install.markdown"]
+	if install.matter.Slug != "installation" {
+		t.Errorf("expected the slug from the front matter, got '%s'", install.matter.Slug)
+	}
+
+	// Only a slug set in the front matter renames an existing doc.
+	if !install.renames("install") || install.renames("installation") {
+		t.Error("expected the front matter slug to rename a doc with a different slug")
+	}
+	if started.renames("getting-started-1") {
+		t.Error("expected the slug from the file name not to rename a doc")
+	}
+
+	// Files must set a title and a category, or use the default category.
+	missing := files["reference/no-frontmatter.md"].model("")
+	if detail := missing.validate(); !strings.Contains(detail, "title") {
+		t.Errorf("expected the title to be required, got '%s'", detail)
+	}
+	missing.Title = types.StringValue("No front matter")
+	if detail := missing.validate(); !strings.Contains(detail, "categorySlug") {
+		t.Errorf("expected the category to be required, got '%s'", detail)
+	}
+	if doc := files["reference/no-frontmatter.md"].model("reference"); doc.CategorySlug.ValueString() != "reference" {
+		t.Errorf("expected the default category, got %s", doc.CategorySlug)
+	}
+
+	if _, err := readDocsDirectory(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestDocsDirectoryOrder(t *testing.T) {
+	docs := map[string]docsDirectoryDocModel{
+		"a/child.md":      {Slug: types.StringValue("child"), ParentDocSlug: types.StringValue("parent")},
+		"b/grandchild.md": {Slug: types.StringValue("grandchild"), ParentDocSlug: types.StringValue("child")},
+		"c/parent.md":     {Slug: types.StringValue("parent")},
+		"d/external.md":   {Slug: types.StringValue("external"), ParentDocSlug: types.StringValue("elsewhere")},
+		"e/self.md":       {Slug: types.StringValue("self"), ParentDocSlug: types.StringValue("self")},
+	}
+
+	expected := []string{"c/parent.md", "d/external.md", "e/self.md", "a/child.md", "b/grandchild.md"}
+	if order := docsDirectoryOrder(docs, nil); strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+
+	// New docs are ordered using the slugs from their files.
+	docs["c/parent.md"] = docsDirectoryDocModel{Slug: types.StringUnknown()}
+	parent := docsDirectoryFile{}
+	parent.matter.Slug = "parent"
+	files := map[string]docsDirectoryFile{"c/parent.md": parent}
+
+	if order := docsDirectoryOrder(docs, files); strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected order %v, got %v", expected, order)
+	}
+}
+
+func TestDocsDirectoryReadDoc(t *testing.T) {
+	defer gock.OffAll()
+
+	ctx := context.Background()
+	client, _ := readme.NewClient(testToken, testURL)
+	r := &docsDirectoryResource{client: client, lookups: newLookupCache(time.Minute)}
+
+	renamed := mockDoc
+	renamed.Slug = "renamed"
+
+	search := func(results ...readme.DocSearchResult) {
+		gock.New(testURL).Post("/docs/search").MatchParam("search", mockDoc.ID).Times(1).Reply(200).
+			JSON(readme.DocSearchResults{Results: results})
+	}
+
+	// A doc renamed in the web UI is found by its ID.
+	search(readme.DocSearchResult{ReferenceID: mockDoc.ID, Slug: renamed.Slug})
+	gock.New(testURL).Get("/docs/renamed").Times(1).Reply(200).JSON(renamed)
+
+	doc := docsDirectoryDocModel{ID: types.StringValue(mockDoc.ID), Slug: types.StringValue(mockDoc.Slug)}
+	response, found, _, err := r.readDoc(ctx, doc, readme.RequestOptions{})
+	if err != nil || !found || response.Slug != renamed.Slug {
+		t.Errorf("expected the renamed doc, got %s, %v, %v", response.Slug, found, err)
+	}
+
+	// A hidden doc isn't found by searching, so it's retrieved by its slug.
+	search()
+	gock.New(testURL).Get("/docs/" + mockDoc.Slug).Times(1).Reply(200).JSON(mockDoc)
+
+	response, found, _, err = r.readDoc(ctx, doc, readme.RequestOptions{})
+	if err != nil || !found || response.ID != mockDoc.ID {
+		t.Errorf("expected the doc by its slug, got %s, %v, %v", response.ID, found, err)
+	}
+
+	// A doc with the slug and another ID isn't the same doc.
+	other := mockDoc
+	other.ID = "6426fbd4ac96740059c3f6cd"
+	search()
+	gock.New(testURL).Get("/docs/" + mockDoc.Slug).Times(1).Reply(200).JSON(other)
+
+	if _, found, _, err = r.readDoc(ctx, doc, readme.RequestOptions{}); err != nil || found {
+		t.Errorf("expected another doc with the slug not to be found, got %v, %v", found, err)
+	}
+
+	// The slug is used when no ID is stored.
+	mockAPIError.Error = "DOC_NOTFOUND"
+	gock.New(testURL).Get("/docs/" + mockDoc.Slug).Times(1).Reply(404).JSON(mockAPIError)
+
+	doc.ID = types.StringNull()
+	if _, found, _, err = r.readDoc(ctx, doc, readme.RequestOptions{}); err != nil || found {
+		t.Errorf("expected a missing doc not to be found, got %v, %v", found, err)
+	}
+
+	if !gock.IsDone() {
+		t.Error("expected all requests to be made")
+	}
+}
+
+func TestDocsDirectoryResource(t *testing.T) {
+	defer gock.OffAll()
+
+	parent := mockDoc
+	parent.ID = "6426fbd4ac96740059c3f6cb"
+	parent.Slug = "parent"
+	parent.Title = "Parent"
+
+	child := mockDoc
+	child.ID = "6426fbd4ac96740059c3f6cc"
+	child.Slug = "child"
+	child.Title = "Child"
+
+	renamedChild := child
+	renamedChild.Slug = "child-renamed"
+
+	// searchGock mocks the search for a doc by its ID.
+	searchGock := func(doc readme.Doc) {
+		gock.New(testURL).
+			Post("/docs/search").
+			MatchParam("search", doc.ID).
+			Persist().
+			Reply(200).
+			JSON(readme.DocSearchResults{
+				Results: []readme.DocSearchResult{{ReferenceID: doc.ID, Slug: doc.Slug}},
+			})
+	}
+
+	dir := writeDocsDirectory(t, map[string]string{
+		"parent.md":       "---\ntitle: Parent\ncategorySlug: " + mockCategory.Slug + "\n---\nParent body\n",
+		"parent/child.md": "---\ntitle: Child\ncategorySlug: " + mockCategory.Slug + "\nparentDocSlug: parent\n---\nChild\n",
+	})
+
+	config := testProviderConfig + `
+		resource "readme_docs_directory" "test" {
+			path = "` + filepath.ToSlash(dir) + `"
+		}`
+
+	// The same files in another directory, to change the path without changing the docs.
+	movedDir := writeDocsDirectory(t, map[string]string{
+		"parent.md":       "---\ntitle: Parent\ncategorySlug: " + mockCategory.Slug + "\n---\nParent body\n",
+		"parent/child.md": "---\ntitle: Child\ncategorySlug: " + mockCategory.Slug + "\nparentDocSlug: parent\n---\nChild\n",
+	})
+	movedConfig := testProviderConfig + `
+		resource "readme_docs_directory" "test" {
+			path = "` + filepath.ToSlash(movedDir) + `"
+		}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create the docs, parents first.
+			{
+				Config: config,
+				PreConfig: func() {
+					mockAPIError.Error = "DOC_NOTFOUND"
+					gock.New(testURL).Get("/docs/parent").Times(1).Reply(404).JSON(mockAPIError)
+					gock.New(testURL).Post("/docs").BodyString(`"slug":"parent"`).Times(1).Reply(201).JSON(parent)
+					gock.New(testURL).Get("/docs/child").Times(1).Reply(404).JSON(mockAPIError)
+					gock.New(testURL).Post("/docs").BodyString(`"slug":"child"`).Times(1).Reply(201).JSON(child)
+					gock.New(testURL).Get("/docs/parent").Persist().Reply(200).JSON(parent)
+					gock.New(testURL).Get("/docs/child").Persist().Reply(200).JSON(child)
+					// The docs are retrieved by their IDs when refreshing.
+					searchGock(parent)
+					searchGock(child)
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_docs_directory.test", "docs.%", "2"),
+					resource.TestCheckResourceAttr("readme_docs_directory.test", "docs.parent.md.slug", "parent"),
+					resource.TestCheckResourceAttr("readme_docs_directory.test", "docs.parent.md.id", parent.ID),
+					resource.TestCheckResourceAttr(
+						"readme_docs_directory.test",
+						"docs.parent/child.md.parent_doc_slug",
+						"parent",
+					),
+					resource.TestCheckResourceAttr("readme_docs_directory.test", "docs.parent/child.md.slug", "child"),
+				),
+			},
+			// Changing the path changes the ID without changing the docs.
+			{
+				Config: movedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_docs_directory.test", "id", filepath.ToSlash(movedDir)),
+					resource.TestCheckResourceAttr("readme_docs_directory.test", "docs.parent.md.id", parent.ID),
+				),
+			},
+			// A doc renamed in the web UI is found by its ID and keeps its new slug.
+			{
+				PreConfig: func() {
+					gock.OffAll()
+					gock.New(testURL).Get("/docs/parent").Persist().Reply(200).JSON(parent)
+					gock.New(testURL).Get("/docs/child-renamed").Persist().Reply(200).JSON(renamedChild)
+					searchGock(parent)
+					searchGock(renamedChild)
+				},
+				Config: movedConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("readme_docs_directory.test", "docs.%", "2"),
+					resource.TestCheckResourceAttr("readme_docs_directory.test", "docs.parent/child.md.id", child.ID),
+					resource.TestCheckResourceAttr(
+						"readme_docs_directory.test", "docs.parent/child.md.slug", renamedChild.Slug),
+				),
+			},
+			// Invalid front matter is reported for the file.
+			{
+				PreConfig: func() {
+					file := filepath.Join(dir, "invalid.md")
+					if err := os.WriteFile(file, []byte("# Missing title\n"), 0o600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`The file invalid.md is invalid`),
+			},
+			// Delete the docs, children first.
+			{
+				PreConfig: func() {
+					if err := os.Remove(filepath.Join(dir, "invalid.md")); err != nil {
+						t.Fatal(err)
+					}
+					gock.New(testURL).Delete("/docs/child-renamed").Times(1).Reply(204)
+					gock.New(testURL).Delete("/docs/parent").Times(1).Reply(204)
+				},
+				Config:  config,
+				Destroy: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,308 @@
+package readme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/yaml.v2"
+)
+
+// docsExportConcurrency is the number of docs retrieved at once.
+const docsExportConcurrency = 4
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &docsExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &docsExportDataSource{}
+)
+
+// docsExportDataSource is the data source implementation.
+type docsExportDataSource struct {
+	client *readme.Client
+	config providerConfig
+}
+
+// docsExportModel maps the response to the Terraform data source schema.
+type docsExportModel struct {
+	ID        types.String `tfsdk:"id"`
+	Files     types.Map    `tfsdk:"files"`
+	OutputDir types.String `tfsdk:"output_dir"`
+	Version   types.String `tfsdk:"version"`
+}
+
+// docsExportFile is a doc to export and the category and parent it's exported under.
+type docsExportFile struct {
+	categorySlug string
+	node         categoryDocNode
+}
+
+// NewDocsExportDataSource is a helper function to simplify the provider implementation.
+func NewDocsExportDataSource() datasource.DataSource {
+	return &docsExportDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *docsExportDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_docs_export"
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *docsExportDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	_ *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg := req.ProviderData.(*providerData)
+	d.client = cfg.client
+	d.config = cfg.config
+}
+
+// Schema defines the schema for the data source.
+func (d *docsExportDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Export all docs of a version on ReadMe.com as Markdown\n\n" +
+			"Each doc in each category is retrieved and rendered as Markdown with its attributes in the " +
+			"front matter: `title`, `categorySlug`, `parentDocSlug`, `order`, `hidden`, `excerpt`, `slug`, " +
+			"and `type`. This is useful for backups and migrations.\n\n" +
+			"The files are keyed by `<category slug>/<parent doc slugs>/<doc slug>.md`, the same layout " +
+			"`readme_docs_directory` reads, so an export written to `output_dir` can be managed with " +
+			"`readme_docs_directory` or `readme_doc` resources.\n\n" +
+			"Each doc is a separate request, so exporting a large project can take a while.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The internal Terraform ID of the data source.",
+				Computed:    true,
+			},
+			"files": schema.MapAttribute{
+				Description: "The Markdown of each doc, keyed by its file path.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"output_dir": schema.StringAttribute{
+				Description: "A directory to write the files to. Existing files with the same path are " +
+					"overwritten and other files are left in place. The files are written each time the " +
+					"data source is read.",
+				Optional: true,
+			},
+			"version": schema.StringAttribute{
+				Description: "The version to export. Defaults to the provider's `config.default_version` " +
+					"if set, otherwise the project's stable version.",
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *docsExportDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state docsExportModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Version.IsNull() && d.config.DefaultVersion.ValueString() != "" {
+		state.Version = d.config.DefaultVersion
+	}
+
+	requestOpts := apiRequestOptions(state.Version)
+
+	exports, ok := d.exportFiles(requestOpts, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	files := d.render(ctx, exports, requestOpts, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if dir := state.OutputDir.ValueString(); dir != "" {
+		if err := writeDocsExport(dir, files); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("output_dir"), "Unable to write the exported docs.", err.Error())
+
+			return
+		}
+	}
+
+	var diags diag.Diagnostics
+	state.Files, diags = types.MapValueFrom(ctx, types.StringType, files)
+	resp.Diagnostics.Append(diags...)
+
+	state.ID = types.StringValue("stable")
+	if state.Version.ValueString() != "" {
+		state.ID = state.Version
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// exportFiles returns the docs in each category of the version, each listed before its children.
+func (d *docsExportDataSource) exportFiles(
+	options readme.RequestOptions,
+	diags *diag.Diagnostics,
+) ([]docsExportFile, bool) {
+	categories, apiResponse, err := d.client.Category.GetAll(options)
+	if err != nil {
+		diags.Append(clientDiagnostic(
+			"Unable to retrieve categories.",
+			err,
+			apiResponse,
+			apiErrorPaths{"VERSION_NOTFOUND": path.Root("version")},
+		))
+
+		return nil, false
+	}
+
+	exports := []docsExportFile{}
+	for _, category := range categories {
+		docs, apiResponse, err := d.client.Category.GetDocs(category.Slug, options)
+		if err != nil {
+			diags.Append(clientDiagnostic(
+				fmt.Sprintf("Unable to retrieve the docs in category %s.", category.Slug),
+				err,
+				apiResponse,
+				nil,
+			))
+
+			return nil, false
+		}
+
+		for _, node := range categoryDocNodes(docs) {
+			exports = append(exports, docsExportFile{categorySlug: category.Slug, node: node})
+		}
+	}
+
+	return exports, true
+}
+
+// render retrieves each doc and returns its Markdown keyed by its file path.
+func (d *docsExportDataSource) render(
+	ctx context.Context,
+	exports []docsExportFile,
+	options readme.RequestOptions,
+	diags *diag.Diagnostics,
+) map[string]string {
+	docs := make([]readme.Doc, len(exports))
+
+	errs := forEachConcurrent(ctx, len(exports), docsExportConcurrency, func(i int) error {
+		doc, apiResponse, err := d.client.Doc.Get(exports[i].node.doc.Slug, options)
+		if err != nil {
+			return errors.New(clientError(err, apiResponse))
+		}
+
+		docs[i] = doc
+
+		return nil
+	})
+
+	files := make(map[string]string, len(exports))
+	for i, export := range exports {
+		if errs[i] != nil {
+			diags.AddError(
+				"Unable to retrieve a doc.",
+				fmt.Sprintf("Unable to retrieve the doc %s: %s", export.node.doc.Slug, errs[i]),
+			)
+
+			continue
+		}
+
+		content, err := docExportMarkdown(docs[i], export.categorySlug, export.node.parentSlug)
+		if err != nil {
+			diags.AddError("Unable to render a doc.", fmt.Sprintf("Unable to render the doc %s: %s", export.node.doc.Slug, err))
+
+			continue
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("exported doc %s", export.node.doc.Slug))
+		files[docExportPath(export.categorySlug, export.node)] = content
+	}
+
+	return files
+}
+
+// docExportPath returns the file path of an exported doc. A child doc is in a directory named
+// after its parent's slug.
+func docExportPath(categorySlug string, node categoryDocNode) string {
+	return categorySlug + "/" + node.path + ".md"
+}
+
+// docExportMarkdown returns the Markdown of a doc with its attributes in the front matter.
+//
+// The keys are in the order the schema description lists them, with the keys of the
+// `frontmatter.ReadmeFrontMatter` struct so the files can be read back. Empty strings are left out,
+// but `order` and `hidden` are always set since a doc without an `order` is ordered last.
+func docExportMarkdown(doc readme.Doc, categorySlug, parentSlug string) (string, error) {
+	matter := yaml.MapSlice{}
+	add := func(key string, value any, always bool) {
+		if always || value != "" {
+			matter = append(matter, yaml.MapItem{Key: key, Value: value})
+		}
+	}
+
+	add("title", doc.Title, false)
+	add("categorySlug", categorySlug, false)
+	add("parentDocSlug", parentSlug, false)
+	add("order", int64(doc.Order), true)
+	add("hidden", doc.Hidden, true)
+	add("excerpt", doc.Excerpt, false)
+	add("slug", doc.Slug, false)
+	add("type", doc.Type, false)
+
+	out, err := yaml.Marshal(matter)
+	if err != nil {
+		return "", fmt.Errorf("unable to render front matter: %w", err)
+	}
+
+	body := doc.Body
+	if body != "" && !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+
+	return "---\n" + string(out) + "---\n" + body, nil
+}
+
+// writeDocsExport writes the exported files to a directory.
+func writeDocsExport(dir string, files map[string]string) error {
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return fmt.Errorf("unable to create the directory for %s: %w", name, err)
+		}
+
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil { //nolint:gosec // The docs aren't secret.
+			return fmt.Errorf("unable to write %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package readme
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestDocExportMarkdown(t *testing.T) {
+	doc := readme.Doc{
+		Body:    "# Install\n\nRun the installer.",
+		Excerpt: "How to install.",
+		Hidden:  true,
+		Order:   2,
+		Slug:    "install",
+		Title:   "Install",
+		Type:    "basic",
+	}
+
+	content, err := docExportMarkdown(doc, "guides", "getting-started")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expect := "---\ntitle: Install\ncategorySlug: guides\nparentDocSlug: getting-started\norder: 2\n" +
+		"hidden: true\nexcerpt: How to install.\nslug: install\ntype: basic\n---\n" +
+		"# Install\n\nRun the installer.\n"
+	if content != expect {
+		t.Errorf("expected the Markdown:\n%s\ngot:\n%s", expect, content)
+	}
+
+	// The exported file is read back with the same attributes.
+	dir := t.TempDir()
+	file := filepath.Join(dir, "install.md")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := readDocsDirectoryFile(file)
+	if err != nil {
+		t.Fatalf("unexpected error reading the export: %s", err)
+	}
+
+	matter := read.matter
+	if matter.Title != doc.Title || matter.CategorySlug != "guides" || matter.ParentDocSlug != "getting-started" ||
+		matter.Order != 2 || matter.Hidden == nil || !*matter.Hidden || matter.Excerpt != doc.Excerpt {
+		t.Errorf("expected the front matter to round trip, got %+v", matter)
+	}
+	if read.body != doc.Body+"\n" {
+		t.Errorf("expected the body to round trip, got %q", read.body)
+	}
+
+	// An order of 0 is kept.
+	doc.Order = 0
+	content, err = docExportMarkdown(doc, "guides", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expect = "---\ntitle: Install\ncategorySlug: guides\norder: 0\nhidden: true\n" +
+		"excerpt: How to install.\nslug: install\ntype: basic\n---\n# Install\n\nRun the installer.\n"
+	if content != expect {
+		t.Errorf("expected the Markdown:\n%s\ngot:\n%s", expect, content)
+	}
+}
+
+func TestDocExportPath(t *testing.T) {
+	node, ok := findCategoryDoc(mockCategoryDocs, "", "child-doc")
+	if !ok {
+		t.Fatal("child-doc not found")
+	}
+
+	if got := docExportPath("guides", node); got != "guides/documentation/parent-doc/child-doc.md" {
+		t.Errorf("expected the child doc under its parents, got %s", got)
+	}
+}
+
+func TestWriteDocsExport(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"guides/top.md":       "top",
+		"guides/top/child.md": "child",
+	}
+
+	if err := writeDocsExport(dir, files); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for name, content := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil || string(data) != content {
+			t.Errorf("expected %s to contain %q, got %q, %v", name, content, data, err)
+		}
+	}
+}
+
+func TestDocsExportDataSource(t *testing.T) {
+	// Close all gocks when completed.
+	defer gock.OffAll()
+
+	child := mockDoc
+	child.Slug = "child-doc"
+	child.Title = "Child Doc"
+	child.Body = "The child."
+
+	dir := t.TempDir()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					gock.OffAll()
+					gock.New(testURL).
+						Get("/categories").
+						MatchHeader("x-readme-version", "1.0").
+						Persist().
+						Reply(200).
+						AddHeader("x-total-count", "1").
+						JSON([]readme.Category{mockCategory})
+					gock.New(testURL).
+						Get("/categories/"+mockCategory.Slug+"/docs").
+						MatchHeader("x-readme-version", "1.0").
+						Persist().
+						Reply(200).
+						JSON([]readme.CategoryDocs{
+							{ID: mockDoc.ID, Slug: mockDoc.Slug, Children: []readme.CategoryDocs{
+								{ID: child.ID, Slug: child.Slug},
+							}},
+						})
+					gock.New(testURL).
+						Get("/docs/"+mockDoc.Slug).
+						MatchHeader("x-readme-version", "1.0").
+						Persist().
+						Reply(200).
+						JSON(mockDoc)
+					gock.New(testURL).
+						Get("/docs/"+child.Slug).
+						MatchHeader("x-readme-version", "1.0").
+						Persist().
+						Reply(200).
+						JSON(child)
+				},
+				Config: testProviderConfig + `
+					data "readme_docs_export" "test" {
+						version    = "1.0"
+						output_dir = "` + filepath.ToSlash(dir) + `"
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.readme_docs_export.test", "id", "1.0"),
+					resource.TestCheckResourceAttr("data.readme_docs_export.test", "files.%", "2"),
+					resource.TestCheckResourceAttrWith(
+						"data.readme_docs_export.test",
+						"files."+mockCategory.Slug+"/"+mockDoc.Slug+"/"+child.Slug+".md",
+						func(value string) error {
+							if !strings.Contains(value, "parentDocSlug: "+mockDoc.Slug) {
+								return fmt.Errorf("expected the child doc to set its parent, got %s", value)
+							}
+
+							return nil
+						},
+					),
+					func(_ *terraform.State) error {
+						_, err := os.Stat(filepath.Join(dir, mockCategory.Slug, mockDoc.Slug+".md"))
+
+						return err
+					},
+				),
+			},
+		},
+	})
+}
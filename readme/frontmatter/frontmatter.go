@@ -7,12 +7,15 @@ package frontmatter
 import (
 	"context"
 	"fmt"
+	"os"
 	"reflect"
 	"strings"
 
 	"github.com/adrg/frontmatter"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"github.com/liveoaklabs/terraform-provider-readme/readme/bodytemplate"
 )
 
 // ReadmeFrontMatter represents the front matter keys available to ReadMe changelogs, custom pages, and docs.
@@ -20,10 +23,16 @@ type ReadmeFrontMatter struct {
 	Body          string                `yaml:"body,omitempty"`          // changelogs, custom pages, docs
 	Category      string                `yaml:"category,omitempty"`      // docs
 	CategorySlug  string                `yaml:"categorySlug,omitempty"`  // docs
+	Deprecated    *bool                 `yaml:"deprecated"`              // docs
 	Error         readme.DocErrorObject `yaml:"error,omitempty"`         // docs
+	Excerpt       string                `yaml:"excerpt,omitempty"`       // docs
 	Hidden        *bool                 `yaml:"hidden"`                  // changelogs, custom pages, docs
 	HTML          string                `yaml:"html,omitempty"`          // custom page
 	HTMLMode      *bool                 `yaml:"htmlmode"`                // custom page
+	Icon          string                `yaml:"icon,omitempty"`          // docs
+	LinkExternal  *bool                 `yaml:"link_external"`           // docs
+	LinkURL       string                `yaml:"link_url,omitempty"`      // docs
+	Metadata      Metadata              `yaml:"metadata,omitempty"`      // docs
 	Order         int64                 `yaml:"order,omitempty"`         // docs
 	ParentDoc     string                `yaml:"parentDoc,omitempty"`     // docs
 	ParentDocSlug string                `yaml:"parentDocSlug,omitempty"` // docs
@@ -32,6 +41,13 @@ type ReadmeFrontMatter struct {
 	Type          string                `yaml:"type,omitempty"`          // changelogs, docs
 }
 
+// Metadata represents the SEO metadata front matter key for docs.
+type Metadata struct {
+	Description string   `yaml:"description,omitempty"`
+	Image       []string `yaml:"image,omitempty"`
+	Title       string   `yaml:"title,omitempty"`
+}
+
 // GetValue parses the 'body' attribute value for Markdown front matter and
 // returns a specified key's value if it's present in the front matter.
 //
@@ -75,3 +91,94 @@ func GetValue(ctx context.Context, body, attribute string) (reflect.Value, strin
 
 	return field, ""
 }
+
+// ReadBody returns the Markdown body of a changelog, custom page, or doc.
+//
+// The 'body' attribute value is returned if it's set. Otherwise, the contents
+// of the file set by the 'body_file' attribute are returned. An empty string is
+// returned if neither attribute is set or the file path isn't known yet.
+func ReadBody(body, bodyFile types.String) (string, error) {
+	if body.ValueString() != "" || bodyFile.IsNull() || bodyFile.IsUnknown() {
+		return body.ValueString(), nil
+	}
+
+	data, err := os.ReadFile(bodyFile.ValueString())
+	if err != nil {
+		return "", fmt.Errorf("unable to read body file: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// RenderBody returns the Markdown body of a changelog, custom page, or doc
+// rendered as a template when the 'template_vars' or 'snippet_dirs'
+// attributes are set. Otherwise, the body is returned as-is.
+//
+// False is returned if the body can't be rendered yet because one of the
+// values isn't known.
+func RenderBody(
+	ctx context.Context,
+	body, bodyFile types.String,
+	vars types.Map,
+	snippetDirs types.List,
+) (string, bool, error) {
+	content, err := ReadBody(body, bodyFile)
+	if err != nil {
+		return "", false, err
+	}
+
+	if (body.IsUnknown() || bodyFile.IsUnknown()) && (!vars.IsNull() || !snippetDirs.IsNull()) {
+		return "", false, nil
+	}
+
+	return RenderContent(ctx, content, bodyFile, vars, snippetDirs)
+}
+
+// RenderContent renders the contents of a body as a template when the
+// 'template_vars' or 'snippet_dirs' attributes are set. Otherwise, the
+// contents are returned as-is.
+//
+// The 'body_file' attribute value is used to name the template in errors.
+// False is returned if the template variables or snippet directories aren't
+// known.
+func RenderContent(
+	ctx context.Context,
+	content string,
+	bodyFile types.String,
+	vars types.Map,
+	snippetDirs types.List,
+) (string, bool, error) {
+	if vars.IsUnknown() || snippetDirs.IsUnknown() {
+		return "", false, nil
+	}
+
+	opts := bodytemplate.Options{}
+	if !vars.IsNull() {
+		opts.Vars = map[string]string{}
+		if diags := vars.ElementsAs(ctx, &opts.Vars, false); diags.HasError() {
+			return "", false, nil
+		}
+	}
+	if !snippetDirs.IsNull() {
+		opts.SnippetDirs = []string{}
+		if diags := snippetDirs.ElementsAs(ctx, &opts.SnippetDirs, false); diags.HasError() {
+			return "", false, nil
+		}
+	}
+
+	if !opts.Enabled() {
+		return content, true, nil
+	}
+
+	name := "body"
+	if !bodyFile.IsNull() {
+		name = bodyFile.ValueString()
+	}
+
+	rendered, err := bodytemplate.Render(name, content, opts)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to render body template: %w", err)
+	}
+
+	return rendered, true, nil
+}
@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -54,12 +58,15 @@ func (m FrontMatterModifier) PlanModifyString(
 	req planmodifier.StringRequest,
 	resp *planmodifier.StringResponse,
 ) {
-	var bodyPlanValue types.String
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("body"), &bodyPlanValue)...)
+	body, diags := planBody(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// If the attribute isn't set, check the body front matter.
 	if req.ConfigValue.IsNull() {
-		value, diag := GetValue(ctx, bodyPlanValue.ValueString(), m.fieldName)
+		value, diag := GetValue(ctx, body, m.fieldName)
 		if diag != "" {
 			resp.Diagnostics.AddError("Error parsing front matter.", diag)
 
@@ -81,12 +88,15 @@ func (m FrontMatterModifier) PlanModifyBool(
 	req planmodifier.BoolRequest,
 	resp *planmodifier.BoolResponse,
 ) {
-	var bodyPlanValue types.String
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("body"), &bodyPlanValue)...)
+	body, diags := planBody(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// If the attribute isn't set, check the body front matter.
 	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
-		value, diag := GetValue(ctx, bodyPlanValue.ValueString(), m.fieldName)
+		value, diag := GetValue(ctx, body, m.fieldName)
 		if diag != "" {
 			resp.Diagnostics.AddError("Error parsing front matter.", diag)
 
@@ -105,12 +115,15 @@ func (m FrontMatterModifier) PlanModifyInt64(
 	req planmodifier.Int64Request,
 	resp *planmodifier.Int64Response,
 ) {
-	var bodyPlanValue types.String
-	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("body"), &bodyPlanValue)...)
+	body, diags := planBody(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// If the attribute isn't set, check the body front matter.
 	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
-		value, diag := GetValue(ctx, bodyPlanValue.ValueString(), m.fieldName)
+		value, diag := GetValue(ctx, body, m.fieldName)
 		if diag != "" {
 			resp.Diagnostics.AddError("Error parsing front matter.", diag)
 
@@ -121,3 +134,38 @@ func (m FrontMatterModifier) PlanModifyInt64(
 		}
 	}
 }
+
+// planBody returns the planned Markdown body from the 'body' attribute or the
+// file set by the 'body_file' attribute, rendered as a template when
+// templating is enabled.
+//
+// An empty body is returned if the body can't be rendered yet because a
+// value isn't known.
+func planBody(ctx context.Context, plan tfsdk.Plan) (string, diag.Diagnostics) {
+	// The body may use a custom string type, so it's read as a generic value and converted.
+	var bodyValue attr.Value
+	var bodyFile types.String
+	var vars types.Map
+	var snippetDirs types.List
+	diags := plan.GetAttribute(ctx, path.Root("body"), &bodyValue)
+	diags.Append(plan.GetAttribute(ctx, path.Root("body_file"), &bodyFile)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("template_vars"), &vars)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("snippet_dirs"), &snippetDirs)...)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	body := types.StringNull()
+	if valuable, ok := bodyValue.(basetypes.StringValuable); ok {
+		var convertDiags diag.Diagnostics
+		body, convertDiags = valuable.ToStringValue(ctx)
+		diags.Append(convertDiags...)
+	}
+
+	value, _, err := RenderBody(ctx, body, bodyFile, vars, snippetDirs)
+	if err != nil {
+		diags.AddAttributeError(path.Root("body"), "Error reading front matter.", err.Error())
+	}
+
+	return value, diags
+}
@@ -198,6 +198,10 @@ func (r *imageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 
 // Create a image and set the initial Terraform state.
 func (r *imageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "image") {
+		return
+	}
+
 	// Retrieve values from plan.
 	var plan imageResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -217,7 +221,7 @@ func (r *imageResource) Create(ctx context.Context, req resource.CreateRequest,
 	// Create the image.
 	image, apiResponse, err := r.client.Image.Upload(imageData, plan.Source.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to create image.", clientError(err, apiResponse))
+		resp.Diagnostics.Append(clientDiagnostic("Unable to create image.", err, apiResponse, nil))
 
 		return
 	}
@@ -278,9 +282,11 @@ func (r *imageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update is not supported for image resources.
 func (r *imageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.config.readOnly(&resp.Diagnostics, "update", "image")
 }
 
 // Delete is not supported for image resources.
 // This removes the resource from state, but does not delete the image from ReadMe.
 func (r *imageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	r.config.readOnly(&resp.Diagnostics, "delete", "image")
 }
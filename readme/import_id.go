@@ -0,0 +1,133 @@
+package readme
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+// importID is a parsed import ID for a versioned resource.
+type importID struct {
+	version    string
+	category   string
+	identifier string
+}
+
+// parseImportID parses an import ID in the form `[version/]identifier`, or
+// `[version/][category/]identifier` when `withCategory` is true.
+//
+// The parts may also be separated with a colon, such as `version:category:identifier`. An empty
+// version uses `defaultVersion`, which is the provider's default version if it's set.
+func parseImportID(id, defaultVersion string, withCategory bool) (importID, error) {
+	separator := ":"
+	if strings.Contains(id, "/") {
+		separator = "/"
+	}
+
+	format := "[version/]identifier"
+	maxParts := 2
+	if withCategory {
+		format = "[version/][category/]identifier"
+		maxParts = 3
+	}
+
+	parts := strings.Split(id, separator)
+	if len(parts) > maxParts {
+		return importID{}, fmt.Errorf(
+			"the import ID '%s' has too many parts; expected the format %s or the same with ':' separators",
+			id, format,
+		)
+	}
+
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return importID{}, fmt.Errorf("the import ID '%s' has an empty part; expected the format %s", id, format)
+		}
+	}
+
+	parsed := importID{identifier: parts[len(parts)-1], version: defaultVersion}
+	if len(parts) > 1 {
+		parsed.version = cleanVersion(parts[0])
+		if parsed.version == "" {
+			return importID{}, fmt.Errorf("the import ID '%s' has an invalid version", id)
+		}
+	}
+	if len(parts) > 2 {
+		parsed.category = parts[1]
+	}
+
+	return parsed, nil
+}
+
+// options returns the request options for the import ID's version.
+func (id importID) options() readme.RequestOptions {
+	return readme.RequestOptions{Version: id.version}
+}
+
+// importMatch is a resource that may be matched by an import ID.
+type importMatch struct {
+	id    string
+	slug  string
+	title string
+}
+
+// matchImportID returns the resource that the identifier of an import ID refers to.
+//
+// A resource matches if its ID or slug is the identifier. If none do, a resource matches if its
+// title is the identifier. An error is returned if nothing matches or if more than one resource
+// has the title, since the import would be ambiguous.
+func matchImportID(kind string, id importID, candidates []importMatch) (importMatch, error) {
+	titles := []importMatch{}
+	for _, candidate := range candidates {
+		if candidate.id == id.identifier || (candidate.slug != "" && candidate.slug == id.identifier) {
+			return candidate, nil
+		}
+		if candidate.title == id.identifier {
+			titles = append(titles, candidate)
+		}
+	}
+
+	where := ""
+	if id.version != "" {
+		where = fmt.Sprintf(" in version %s", id.version)
+	}
+	if id.category != "" {
+		where += fmt.Sprintf(" in category %s", id.category)
+	}
+
+	switch len(titles) {
+	case 0:
+		return importMatch{}, fmt.Errorf("no %s with the ID, slug, or title '%s' was found%s", kind, id.identifier, where)
+	case 1:
+		return titles[0], nil
+	}
+
+	ids := make([]string, 0, len(titles))
+	for _, match := range titles {
+		if match.slug != "" {
+			ids = append(ids, match.slug)
+		} else {
+			ids = append(ids, match.id)
+		}
+	}
+	sort.Strings(ids)
+
+	return importMatch{}, fmt.Errorf(
+		"the title '%s' matches more than one %s%s: %s. Import by ID or slug instead",
+		id.identifier, kind, where, strings.Join(ids, ", "),
+	)
+}
+
+// categoryDocsMatches flattens a category's docs and their children into import matches.
+func categoryDocsMatches(docs []readme.CategoryDocs) []importMatch {
+	matches := []importMatch{}
+	walkCategoryDocs(docs, func(node categoryDocNode) bool {
+		matches = append(matches, importMatch{id: node.doc.ID, slug: node.doc.Slug, title: node.doc.Title})
+
+		return true
+	})
+
+	return matches
+}
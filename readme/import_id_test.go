@@ -0,0 +1,89 @@
+package readme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+func TestParseImportID(t *testing.T) {
+	tests := []struct {
+		id           string
+		withCategory bool
+		expected     importID
+		err          string
+	}{
+		{id: "my-doc", withCategory: true, expected: importID{version: "1.0", identifier: "my-doc"}},
+		{id: "2.0/my-doc", withCategory: true, expected: importID{version: "2.0", identifier: "my-doc"}},
+		{id: "v2.0:my-doc", withCategory: true, expected: importID{version: "2.0", identifier: "my-doc"}},
+		{
+			id:           "2.0/guides/my-doc",
+			withCategory: true,
+			expected:     importID{version: "2.0", category: "guides", identifier: "my-doc"},
+		},
+		{
+			id:           "2.0:guides:Getting Started",
+			withCategory: true,
+			expected:     importID{version: "2.0", category: "guides", identifier: "Getting Started"},
+		},
+		{id: "2.0/guides/my-doc", err: "too many parts"},
+		{id: "2.0//my-doc", withCategory: true, err: "empty part"},
+		{id: "v/my-doc", err: "invalid version"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.id, func(t *testing.T) {
+			id, err := parseImportID(tc.id, "1.0", tc.withCategory)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("expected an error containing '%s', got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if id != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, id)
+			}
+		})
+	}
+}
+
+func TestMatchImportID(t *testing.T) {
+	candidates := []importMatch{
+		{id: "1", slug: "getting-started", title: "Getting Started"},
+		{id: "2", slug: "overview", title: "Overview"},
+		{id: "3", slug: "overview-1", title: "Overview"},
+	}
+
+	for identifier, expected := range map[string]string{"1": "1", "overview": "2", "Getting Started": "1"} {
+		match, err := matchImportID("doc", importID{identifier: identifier}, candidates)
+		if err != nil || match.id != expected {
+			t.Errorf("expected '%s' to match %s, got %+v, %v", identifier, expected, match, err)
+		}
+	}
+
+	_, err := matchImportID("doc", importID{version: "2.0", identifier: "Overview"}, candidates)
+	if err == nil || !strings.Contains(err.Error(), "more than one doc in version 2.0: overview, overview-1") {
+		t.Errorf("expected an ambiguous match error, got %v", err)
+	}
+
+	_, err = matchImportID("doc", importID{category: "guides", identifier: "missing"}, candidates)
+	if err == nil || !strings.Contains(err.Error(), "was found in category guides") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestCategoryDocsMatches(t *testing.T) {
+	docs := []readme.CategoryDocs{
+		{ID: "1", Slug: "parent", Children: []readme.CategoryDocs{{ID: "2", Slug: "child"}}},
+	}
+
+	if matches := categoryDocsMatches(docs); len(matches) != 2 || matches[1].slug != "child" {
+		t.Errorf("expected the parent and child docs, got %+v", matches)
+	}
+}
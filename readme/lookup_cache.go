@@ -0,0 +1,160 @@
+package readme
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+// defaultLookupCacheTTL is how long a cached lookup is used before it's requested again.
+const defaultLookupCacheTTL = 5 * time.Minute
+
+// lookupKind identifies the type of value a cached lookup resolves.
+type lookupKind string
+
+const (
+	// lookupVersion resolves a version ID to its "clean" semver version.
+	lookupVersion lookupKind = "version"
+	// lookupCategory resolves a category ID to its slug.
+	lookupCategory lookupKind = "category"
+	// lookupDoc resolves a doc ID to its slug.
+	lookupDoc lookupKind = "doc"
+	// lookupLinkTarget resolves a version and doc slug to the slug when the doc exists or is
+	// planned, which is used to check links between docs.
+	lookupLinkTarget lookupKind = "link_target"
+	// lookupLinkCategory resolves a version and category slug to the slug once the docs in the
+	// category are registered as link targets.
+	lookupLinkCategory lookupKind = "link_category"
+)
+
+// lookupCache caches the lookups that are repeated for many resources during a single Terraform
+// run, such as resolving a version ID to its version or a category ID to its slug.
+//
+// The cache is shared by all resources and data sources through the provider data. Entries
+// expire after a TTL and are invalidated when a resource of the same kind is changed. All
+// methods may be called on a nil cache, in which case every lookup is requested from the API.
+type lookupCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]lookupEntry
+}
+
+// lookupEntry is a cached lookup value and when it expires.
+type lookupEntry struct {
+	value   string
+	expires time.Time
+}
+
+// newLookupCache returns an empty lookup cache with entries that expire after the `ttl`.
+func newLookupCache(ttl time.Duration) *lookupCache {
+	return &lookupCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]lookupEntry{},
+	}
+}
+
+// lookupKey returns the cache key for a lookup.
+func lookupKey(kind lookupKind, key string) string {
+	return string(kind) + ":" + key
+}
+
+// get returns a cached value if it exists and hasn't expired.
+func (c *lookupCache) get(kind lookupKind, key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[lookupKey(kind, key)]
+	if !ok || c.now().After(entry.expires) {
+		return "", false
+	}
+
+	return entry.value, true
+}
+
+// set caches a value. Empty values are not cached.
+func (c *lookupCache) set(kind lookupKind, key, value string) {
+	if c == nil || key == "" || value == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[lookupKey(kind, key)] = lookupEntry{value: value, expires: c.now().Add(c.ttl)}
+}
+
+// invalidate removes all cached values of a kind.
+func (c *lookupCache) invalidate(kind lookupKind) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prefix := lookupKey(kind, "")
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// versionClean returns the "clean" version for a version ID, using the cache when possible.
+func (c *lookupCache) versionClean(ctx context.Context, client *readme.Client, versionID string) string {
+	if version, ok := c.get(lookupVersion, versionID); ok {
+		return version
+	}
+
+	version := versionClean(ctx, client, versionID)
+	c.set(lookupVersion, versionID, version)
+
+	return version
+}
+
+// categorySlug returns the slug for a category ID, using the cache when possible.
+func (c *lookupCache) categorySlug(
+	client *readme.Client,
+	categoryID string,
+	options readme.RequestOptions,
+) (string, *readme.APIResponse, error) {
+	if slug, ok := c.get(lookupCategory, categoryID); ok {
+		return slug, nil, nil
+	}
+
+	category, apiResponse, err := client.Category.Get(IDPrefix+categoryID, options)
+	if err != nil {
+		return "", apiResponse, err //nolint:wrapcheck // The error is formatted by the caller.
+	}
+	c.set(lookupCategory, categoryID, category.Slug)
+
+	return category.Slug, apiResponse, nil
+}
+
+// docSlug returns the slug for a doc ID, using the cache when possible.
+func (c *lookupCache) docSlug(
+	client *readme.Client,
+	docID string,
+	options readme.RequestOptions,
+) (string, *readme.APIResponse, error) {
+	if slug, ok := c.get(lookupDoc, docID); ok {
+		return slug, nil, nil
+	}
+
+	doc, apiResponse, err := client.Doc.Get(IDPrefix+docID, options)
+	if err != nil {
+		return "", apiResponse, err //nolint:wrapcheck // The error is formatted by the caller.
+	}
+	c.set(lookupDoc, docID, doc.Slug)
+
+	return doc.Slug, apiResponse, nil
+}
@@ -0,0 +1,96 @@
+package readme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestLookupCache(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newLookupCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.set(lookupCategory, "123", "guides")
+	cache.set(lookupDoc, "456", "intro")
+
+	if value, ok := cache.get(lookupCategory, "123"); !ok || value != "guides" {
+		t.Errorf("expected cached category slug 'guides', got '%s'", value)
+	}
+
+	// Lookups of different kinds don't collide.
+	if _, ok := cache.get(lookupDoc, "123"); ok {
+		t.Error("expected no cached doc slug for a category ID")
+	}
+
+	// Invalidating a kind doesn't affect other kinds.
+	cache.invalidate(lookupCategory)
+	if _, ok := cache.get(lookupCategory, "123"); ok {
+		t.Error("expected category lookups to be invalidated")
+	}
+	if _, ok := cache.get(lookupDoc, "456"); !ok {
+		t.Error("expected doc lookups to remain cached")
+	}
+
+	// Entries expire after the TTL.
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.get(lookupDoc, "456"); ok {
+		t.Error("expected the doc lookup to expire")
+	}
+
+	// Empty values aren't cached.
+	cache.set(lookupVersion, "789", "")
+	if _, ok := cache.get(lookupVersion, "789"); ok {
+		t.Error("expected an empty value not to be cached")
+	}
+}
+
+func TestLookupCache_Nil(t *testing.T) {
+	var cache *lookupCache
+
+	cache.set(lookupCategory, "123", "guides")
+	cache.invalidate(lookupCategory)
+
+	if _, ok := cache.get(lookupCategory, "123"); ok {
+		t.Error("expected a nil cache to never return a value")
+	}
+}
+
+func TestLookupCache_CategorySlug(t *testing.T) {
+	defer gock.OffAll()
+
+	// The category is only requested once.
+	gock.New(testURL).
+		Get("/categories").
+		MatchParam("perPage", "100").
+		MatchParam("page", "1").
+		Times(1).
+		Reply(200).
+		AddHeader("link", `'<>; rel="next", <>; rel="prev", <>; rel="last"'`).
+		AddHeader("x-total-count", "1").
+		JSON(mockCategoryList)
+	gock.New(testURL).
+		Get("/categories/" + mockCategory.Slug).
+		Times(1).
+		Reply(200).
+		JSON(mockCategory)
+
+	client, _ := readme.NewClient(testToken, testURL)
+	cache := newLookupCache(time.Minute)
+
+	for range 2 {
+		slug, _, err := cache.categorySlug(client, mockCategory.ID, readme.RequestOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if slug != mockCategory.Slug {
+			t.Errorf("expected slug '%s', got '%s'", mockCategory.Slug, slug)
+		}
+	}
+
+	if !gock.IsDone() {
+		t.Error("expected all mocked requests to be made")
+	}
+}
@@ -0,0 +1,199 @@
+// Package markdown provides a string type for Markdown bodies with semantic
+// equality that ignores the formatting ReadMe changes when it saves a body.
+//
+// ReadMe normalizes bodies, for example by trimming whitespace, expanding
+// escaped newlines, and rewriting list markers. Comparing the body returned by
+// the API to the configured body with `Normalize` means a body only shows a
+// difference when its content changed.
+package markdown
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = Type{}
+	_ basetypes.StringValuableWithSemanticEquals = Value{}
+)
+
+var (
+	// frontMatterRegex matches YAML front matter at the start of a body.
+	frontMatterRegex = regexp.MustCompile(`\A---[ \t]*\n(?:.*\n)*?---[ \t]*(?:\n|\z)`)
+	// bulletRegex matches the marker of an unordered list item.
+	bulletRegex = regexp.MustCompile(`^(\s*)[*+-](\s+)`)
+	// orderedRegex matches the marker of an ordered list item that uses a parenthesis.
+	orderedRegex = regexp.MustCompile(`^(\s*\d+)\)(\s+)`)
+)
+
+// Normalize returns a Markdown body in the form ReadMe saves it, which is used to compare bodies.
+//
+// Front matter is removed because ReadMe doesn't save it as part of the body. The attributes set
+// from the front matter show their own differences.
+func Normalize(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	body = strings.ReplaceAll(body, `\n`, "\n")
+	body = frontMatterRegex.ReplaceAllString(body, "")
+
+	lines := []string{}
+	fence := ""
+	blank := false
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, " \t")
+		trimmed := strings.TrimSpace(line)
+
+		// Lines in fenced code blocks are only trimmed.
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			lines = append(lines, line)
+			blank = false
+
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+		}
+
+		// Consecutive blank lines are collapsed into one.
+		if line == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+
+		if fence == "" {
+			line = bulletRegex.ReplaceAllString(line, "$1-$2")
+			line = orderedRegex.ReplaceAllString(line, "$1.$2")
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// Type is a string type for Markdown bodies.
+type Type struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t Type) String() string {
+	return "markdown.Type"
+}
+
+// ValueType returns the Value type.
+func (t Type) ValueType(_ context.Context) attr.Value {
+	return Value{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t Type) Equal(o attr.Type) bool {
+	other, ok := o.(Type)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t Type) ValueFromString(
+	_ context.Context,
+	in basetypes.StringValue,
+) (basetypes.StringValuable, diag.Diagnostics) {
+	return Value{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t Type) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// Value is a Markdown body that's semantically equal to another body with the same normalized
+// content.
+type Value struct {
+	basetypes.StringValue
+}
+
+// Type returns a Type.
+func (v Value) Type(_ context.Context) attr.Type {
+	return Type{}
+}
+
+// Equal returns true if the given value is equivalent.
+func (v Value) Equal(o attr.Value) bool {
+	other, ok := o.(Value)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if the normalized bodies are equal.
+func (v Value) StringSemanticEquals(
+	_ context.Context,
+	newValuable basetypes.StringValuable,
+) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(Value)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+
+		return false, diags
+	}
+
+	return Normalize(v.ValueString()) == Normalize(newValue.ValueString()), diags
+}
+
+// NewValue returns a known Value.
+func NewValue(value string) Value {
+	return Value{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewNullValue returns a null Value.
+func NewNullValue() Value {
+	return Value{StringValue: basetypes.NewStringNull()}
+}
+
+// NewUnknownValue returns an unknown Value.
+func NewUnknownValue() Value {
+	return Value{StringValue: basetypes.NewStringUnknown()}
+}
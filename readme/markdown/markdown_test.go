@@ -0,0 +1,105 @@
+package markdown
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		expect string
+	}{
+		{"unchanged", "# Title\n\nBody", "# Title\n\nBody"},
+		{"surrounding whitespace", "\n\n  Body text  \n\n", "Body text"},
+		{"trailing whitespace", "line one  \nline two\t", "line one\nline two"},
+		{"windows newlines", "line one\r\nline two", "line one\nline two"},
+		{"escaped newlines", `line one\nline two`, "line one\nline two"},
+		{"repeated blank lines", "one\n\n\n\ntwo", "one\n\ntwo"},
+		{"front matter", "---\ntitle: Example\nhidden: true\n---\nBody", "Body"},
+		{"only front matter", "---\ntitle: Example\n---", ""},
+		{"bullet markers", "* one\n+ two\n  * nested", "- one\n- two\n  - nested"},
+		{"ordered markers", "1) one\n2) two", "1. one\n2. two"},
+		{"emphasis isn't a list", "*emphasis* and **bold**", "*emphasis* and **bold**"},
+		{
+			"fenced code",
+			"```md\n* item\n\n\n1) item  \n```\n* item",
+			"```md\n* item\n\n\n1) item\n```\n- item",
+		},
+		{"tilde fenced code", "~~~\n+ item\n~~~", "~~~\n+ item\n~~~"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Normalize(tc.body); got != tc.expect {
+				t.Errorf("expected %q, got %q", tc.expect, got)
+			}
+		})
+	}
+}
+
+func TestValueStringSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		old    string
+		new    string
+		expect bool
+	}{
+		{"identical", "Body", "Body", true},
+		{"formatting", "---\ntitle: Example\n---\n* one  \n* two\n", "- one\n- two", true},
+		{"content", "* one\n* two", "- one\n- three", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			equal, diags := NewValue(tc.old).StringSemanticEquals(ctx, NewValue(tc.new))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != tc.expect {
+				t.Errorf("expected %t, got %t", tc.expect, equal)
+			}
+		})
+	}
+
+	_, diags := NewValue("Body").StringSemanticEquals(ctx, basetypes.NewStringValue("Body"))
+	if !diags.HasError() {
+		t.Error("expected an error comparing a different value type")
+	}
+}
+
+func TestTypeValueFromTerraform(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		in     tftypes.Value
+		expect Value
+	}{
+		{"known", tftypes.NewValue(tftypes.String, "Body"), NewValue("Body")},
+		{"null", tftypes.NewValue(tftypes.String, nil), NewNullValue()},
+		{"unknown", tftypes.NewValue(tftypes.String, tftypes.UnknownValue), NewUnknownValue()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Type{}.ValueFromTerraform(ctx, tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(tc.expect) {
+				t.Errorf("expected %s, got %s", tc.expect, got)
+			}
+		})
+	}
+
+	if NewValue("Body").Equal(basetypes.NewStringValue("Body")) {
+		t.Error("expected a markdown value not to equal a string value")
+	}
+}
@@ -0,0 +1,159 @@
+package readme
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/adrg/frontmatter"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseFrontMatterFunction{}
+
+// parseFrontMatterFunction is the function implementation.
+type parseFrontMatterFunction struct{}
+
+// NewParseFrontMatterFunction is a helper function to simplify the provider implementation.
+func NewParseFrontMatterFunction() function.Function {
+	return &parseFrontMatterFunction{}
+}
+
+// Metadata returns the function name.
+func (f *parseFrontMatterFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "parse_frontmatter"
+}
+
+// Definition defines the parameters and return type for the function.
+func (f *parseFrontMatterFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Parse the front matter of a Markdown document.",
+		Description: "Parses the YAML front matter of a Markdown document and returns it as an object. " +
+			"An empty object is returned if the document doesn't have front matter.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "body",
+				Description: "The Markdown document.",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+// Run parses the front matter.
+func (f *parseFrontMatterFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var body string
+	resp.Error = req.Arguments.Get(ctx, &body)
+	if resp.Error != nil {
+		return
+	}
+
+	matter, _, err := parseFrontMatter(body)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to parse front matter: %s", err))
+
+		return
+	}
+
+	value, err := frontMatterToValue(matter)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to convert front matter: %s", err))
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(value))
+}
+
+// parseFrontMatter returns the front matter keys of a Markdown document and the content that
+// follows the front matter.
+func parseFrontMatter(body string) (map[string]any, string, error) {
+	matter := map[string]any{}
+
+	content, err := frontmatter.Parse(strings.NewReader(body), &matter)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	return matter, string(content), nil
+}
+
+// frontMatterToValue converts a value decoded from YAML to a Terraform value.
+//
+// Maps are converted to objects and sequences to tuples since their elements may have different
+// types. A null value is returned as a null string since Terraform values must have a type.
+func frontMatterToValue(value any) (attr.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case int:
+		return types.NumberValue(new(big.Float).SetInt64(int64(v))), nil
+	case int64:
+		return types.NumberValue(new(big.Float).SetInt64(v)), nil
+	case uint64:
+		return types.NumberValue(new(big.Float).SetUint64(v)), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(v)), nil
+	case []any:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, item := range v {
+			elem, err := frontMatterToValue(item)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes = append(elemTypes, elem.Type(context.Background()))
+			elems = append(elems, elem)
+		}
+
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to create tuple: %v", diags)
+		}
+
+		return tuple, nil
+	case map[any]any:
+		matter := make(map[string]any, len(v))
+		for key, item := range v {
+			matter[fmt.Sprint(key)] = item
+		}
+
+		return frontMatterToValue(matter)
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for key, item := range v {
+			elem, err := frontMatterToValue(item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			attrTypes[key] = elem.Type(context.Background())
+			attrs[key] = elem
+		}
+
+		object, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("unable to create object: %v", diags)
+		}
+
+		return object, nil
+	}
+
+	return types.StringValue(fmt.Sprint(value)), nil
+}
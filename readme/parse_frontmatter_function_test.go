@@ -0,0 +1,74 @@
+package readme
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseFrontMatterFunction(t *testing.T) {
+	body := `---
+title: Getting Started
+order: 2
+hidden: false
+metadata:
+  keywords:
+    - readme
+    - 3
+excerpt:
+---
+# Welcome
+`
+
+	resp := runFunction(NewParseFrontMatterFunction(), types.DynamicUnknown(), types.StringValue(body))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	keywords := types.TupleValueMust(
+		[]attr.Type{types.StringType, types.NumberType},
+		[]attr.Value{types.StringValue("readme"), types.NumberValue(big.NewFloat(3))},
+	)
+	expected := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{
+			"title":    types.StringType,
+			"order":    types.NumberType,
+			"hidden":   types.BoolType,
+			"excerpt":  types.StringType,
+			"metadata": types.ObjectType{AttrTypes: map[string]attr.Type{"keywords": keywords.Type(context.Background())}},
+		},
+		map[string]attr.Value{
+			"title":   types.StringValue("Getting Started"),
+			"order":   types.NumberValue(big.NewFloat(2)),
+			"hidden":  types.BoolValue(false),
+			"excerpt": types.StringNull(),
+			"metadata": types.ObjectValueMust(
+				map[string]attr.Type{"keywords": keywords.Type(context.Background())},
+				map[string]attr.Value{"keywords": keywords},
+			),
+		},
+	))
+
+	if got := resp.Result.Value(); !got.Equal(expected) {
+		t.Errorf("unexpected front matter:\nexpected: %s\ngot: %s", expected, got)
+	}
+
+	// A document without front matter returns an empty object.
+	resp = runFunction(NewParseFrontMatterFunction(), types.DynamicUnknown(), types.StringValue("# Welcome\n"))
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	empty := types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{}))
+	if got := resp.Result.Value(); !got.Equal(empty) {
+		t.Errorf("expected an empty object, got %s", got)
+	}
+
+	// Invalid front matter is an error.
+	resp = runFunction(NewParseFrontMatterFunction(), types.DynamicUnknown(), types.StringValue("---\n: :\n---\n"))
+	if resp.Error == nil {
+		t.Error("expected an error for invalid front matter")
+	}
+}
@@ -104,10 +104,12 @@ func (d *projectDataSource) Read(
 	// Get project metadata from ReadMe API
 	project, apiResponse, err := d.client.Project.Get()
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(clientDiagnostic(
 			"Unable to retrieve project metadata.",
-			clientError(err, apiResponse),
-		)
+			err,
+			apiResponse,
+			nil,
+		))
 
 		return
 	}
@@ -144,5 +146,5 @@ func (d *projectDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
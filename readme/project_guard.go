@@ -0,0 +1,108 @@
+package readme
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+)
+
+// projectGuard is the expected identity of the ReadMe project the API token belongs to.
+//
+// It guards against applying changes to the wrong project when the provider is configured with
+// the wrong API token.
+type projectGuard struct {
+	Subdomain string
+	Name      string
+}
+
+// enabled determines if any project identity is expected.
+func (g projectGuard) enabled() bool {
+	return g.Subdomain != "" || g.Name != ""
+}
+
+// verify retrieves the project the client's API token belongs to and returns an error diagnostic
+// if it doesn't match the expected identity.
+//
+// The `tokenSource` describes where the API token was found for use in the diagnostic.
+func (g projectGuard) verify(ctx context.Context, client *readme.Client, tokenSource string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	project, apiResponse, err := client.Project.Get()
+	if err != nil {
+		diags.AddError(
+			"Unable to verify the ReadMe project.",
+			"The provider is configured with an expected project, but the project for the API token "+
+				"could not be retrieved.\n"+clientError(err, apiResponse),
+		)
+
+		return diags
+	}
+
+	tflog.Debug(ctx, "Verifying ReadMe project", map[string]any{
+		"subdomain":          project.SubDomain,
+		"name":               project.Name,
+		"expected_subdomain": g.Subdomain,
+		"expected_name":      g.Name,
+	})
+
+	if g.Subdomain != "" && !strings.EqualFold(strings.TrimSpace(project.SubDomain), strings.TrimSpace(g.Subdomain)) {
+		diags.AddAttributeError(
+			path.Root("expected_project_subdomain"),
+			"ReadMe project mismatch.",
+			fmt.Sprintf("The API token belongs to the project with the subdomain '%s', but '%s' is expected. "+
+				"Ensure the API token from %s is for the intended project.", project.SubDomain, g.Subdomain, tokenSource),
+		)
+	}
+
+	if g.Name != "" && strings.TrimSpace(project.Name) != strings.TrimSpace(g.Name) {
+		diags.AddAttributeError(
+			path.Root("expected_project_name"),
+			"ReadMe project mismatch.",
+			fmt.Sprintf("The API token belongs to the project named '%s', but '%s' is expected. "+
+				"Ensure the API token from %s is for the intended project.", project.Name, g.Name, tokenSource),
+		)
+	}
+
+	return diags
+}
+
+// verifyProject verifies the project identity for the API token, skipping the check if the same
+// token and identity were already verified by this provider instance.
+func (p *readmeProvider) verifyProject(
+	ctx context.Context,
+	client *readme.Client,
+	apiURL, apiToken, tokenSource string,
+	guard projectGuard,
+) diag.Diagnostics {
+	if !guard.enabled() {
+		return nil
+	}
+
+	key := strings.Join([]string{apiURL, apiToken, guard.Subdomain, guard.Name}, "\x00")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.verifiedProjects[key] {
+		tflog.Debug(ctx, "ReadMe project already verified")
+
+		return nil
+	}
+
+	diags := guard.verify(ctx, client, tokenSource)
+	if diags.HasError() {
+		return diags
+	}
+
+	if p.verifiedProjects == nil {
+		p.verifiedProjects = map[string]bool{}
+	}
+	p.verifiedProjects[key] = true
+
+	return diags
+}
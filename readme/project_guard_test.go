@@ -0,0 +1,90 @@
+package readme
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// mockProject is the project returned by the mocked project API.
+var mockProject = map[string]string{
+	"name":      "Test Project",
+	"subdomain": "terraform-test",
+	"jwtSecret": "SuperSecret",
+	"baseUrl":   "http://terraform-test.readme.io",
+	"plan":      "enterprise",
+}
+
+func TestProvider_ExpectedProject(t *testing.T) {
+	defer gock.OffAll()
+
+	gock.New(testURL).Get("/").Persist().Reply(200).JSON(mockProject)
+
+	providerConfig := func(subdomain, name string) string {
+		return `
+		provider "readme" {
+			api_token                  = "` + testToken + `"
+			api_url                    = "` + testURL + `"
+			expected_project_subdomain = "` + subdomain + `"
+			expected_project_name      = "` + name + `"
+		}
+		data "readme_project" "test" {}
+		`
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The project matches.
+			{
+				Config: providerConfig("Terraform-Test", "Test Project"),
+				Check: resource.TestCheckResourceAttr(
+					"data.readme_project.test",
+					"name",
+					"Test Project",
+				),
+			},
+			// The subdomain doesn't match.
+			{
+				Config:      providerConfig("production", "Test Project"),
+				ExpectError: regexp.MustCompile(`subdomain 'terraform-test', but 'production' is expected`),
+			},
+			// The name doesn't match.
+			{
+				Config:      providerConfig("terraform-test", "Production"),
+				ExpectError: regexp.MustCompile(`project named 'Test Project', but 'Production' is expected`),
+			},
+		},
+	})
+}
+
+func TestProvider_VerifyProject_Cached(t *testing.T) {
+	defer gock.OffAll()
+
+	// The project is only requested once.
+	gock.New(testURL).Get("/").Times(1).Reply(200).JSON(mockProject)
+
+	client, _ := readme.NewClient(testToken, testURL)
+	provider := &readmeProvider{}
+	guard := projectGuard{Subdomain: "terraform-test"}
+
+	for range 2 {
+		if diags := provider.verifyProject(context.Background(), client, testURL, testToken, "test", guard); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+	}
+
+	if !gock.IsDone() {
+		t.Error("expected the project to be requested")
+	}
+
+	// Nothing is requested when no project is expected.
+	if diags := provider.verifyProject(context.Background(), client, testURL, testToken, "test", projectGuard{}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+}
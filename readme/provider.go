@@ -5,8 +5,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -26,7 +29,10 @@ const (
 )
 
 // Ensure the implementation satisfies the expected interfaces
-var _ provider.Provider = &readmeProvider{}
+var (
+	_ provider.Provider              = &readmeProvider{}
+	_ provider.ProviderWithFunctions = &readmeProvider{}
+)
 
 // New is a helper function to simplify provider server and testing implementation.
 func New(version string) func() provider.Provider {
@@ -40,22 +46,42 @@ func New(version string) func() provider.Provider {
 // readmeProvider is the provider implementation.
 type readmeProvider struct {
 	Version string
+
+	// mu guards verifiedProjects.
+	mu sync.Mutex
+	// verifiedProjects records the API tokens and expected project identities that have been
+	// verified so the project is only requested once per provider instance.
+	verifiedProjects map[string]bool
 }
 
 // readmeProviderModel maps provider schema data to a Go type.
 type readmeProviderModel struct {
-	APIToken types.String `tfsdk:"api_token"`
-	APIURL   types.String `tfsdk:"api_url"`
-	Config   types.Object `tfsdk:"config"`
+	APIToken        types.String `tfsdk:"api_token"`
+	APITokenFile    types.String `tfsdk:"api_token_file"`
+	APITokenCommand types.List   `tfsdk:"api_token_command"`
+	APIURL          types.String `tfsdk:"api_url"`
+	Config          types.Object `tfsdk:"config"`
+	Retry           types.Object `tfsdk:"retry"`
+
+	MaxConcurrentRequests    types.Int64  `tfsdk:"max_concurrent_requests"`
+	ExpectedProjectSubdomain types.String `tfsdk:"expected_project_subdomain"`
+	ExpectedProjectName      types.String `tfsdk:"expected_project_name"`
 }
 
 type providerData struct {
 	client *readme.Client
 	config providerConfig
+	// locks serializes writes to docs within the same category across all resources.
+	locks *categoryLocks
+	// lookups caches version, category, and doc lookups across all resources and data sources.
+	lookups *lookupCache
 }
 
 type providerConfig struct {
-	DestroyChildDocs types.Bool `tfsdk:"destroy_child_docs"`
+	DestroyChildDocs    types.Bool   `tfsdk:"destroy_child_docs"`
+	ReadOnly            types.Bool   `tfsdk:"read_only"`
+	DefaultVersion      types.String `tfsdk:"default_version"`
+	DefaultCategorySlug types.String `tfsdk:"default_category_slug"`
 }
 
 // saveAction is a custom type to represent the action to take when saving a
@@ -89,12 +115,30 @@ func (p *readmeProvider) Schema(
 		Attributes: map[string]schema.Attribute{
 			"api_token": schema.StringAttribute{
 				Description: "Client token for accessing the ReadMe API. May alternatively be set with the " +
-					"README_API_TOKEN environment variable.",
+					"api_token_file or api_token_command attributes or the README_API_TOKEN environment variable. " +
+					"If none are set, the API key stored by the rdme CLI is used. Only one of api_token, " +
+					"api_token_file, and api_token_command may be set. These take precedence over the " +
+					"environment variable, which takes precedence over the rdme CLI.",
 				MarkdownDescription: "Client token for accessing the ReadMe API. May alternatively be set with the " +
-					"`README_API_TOKEN` environment variable.",
+					"`api_token_file` or `api_token_command` attributes or the `README_API_TOKEN` environment " +
+					"variable. If none are set, the API key stored by the [rdme CLI](https://github.com/readmeio/rdme) " +
+					"with `rdme login` is used. Only one of `api_token`, `api_token_file`, and `api_token_command` " +
+					"may be set. These take precedence over the environment variable, which takes precedence over " +
+					"the rdme CLI.",
 				Optional:  true,
 				Sensitive: true,
 			},
+			"api_token_file": schema.StringAttribute{
+				Description: "Path to a file containing the client token for accessing the ReadMe API. " +
+					"Surrounding whitespace is ignored.",
+				Optional: true,
+			},
+			"api_token_command": schema.ListAttribute{
+				Description: "A command and its arguments to run to get the client token for accessing the ReadMe " +
+					"API, such as a secrets manager CLI. The command must write the token to stdout.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 			"api_url": schema.StringAttribute{
 				Description: "URL for accessing the ReadMe API. May also be set with the README_API_URL " +
 					"environment variable or left unset to use the default.",
@@ -109,6 +153,70 @@ func (p *readmeProvider) Schema(
 						Description: "Destroy child docs when destroying a parent doc.",
 						Optional:    true,
 					},
+					"read_only": schema.BoolAttribute{
+						Description: "Reject all changes to resources. Creating, updating, or destroying any resource " +
+							"fails before a request is made to the ReadMe API. Plans and data sources are not affected.",
+						Optional: true,
+					},
+					"default_version": schema.StringAttribute{
+						Description: "The version to use for docs and categories that don't set a version and for " +
+							"new API specifications that don't set a semver. If unset, the project's default version " +
+							"in ReadMe is used.",
+						MarkdownDescription: "The version to use for docs and categories that don't set a `version` " +
+							"and for new API specifications that don't set a `semver`. If unset, the project's " +
+							"default version in ReadMe is used.",
+						Optional: true,
+					},
+					"default_category_slug": schema.StringAttribute{
+						Description: "The category slug to use for docs that don't set a category or category slug " +
+							"with an attribute or in the body front matter.",
+						MarkdownDescription: "The category slug to use for docs that don't set a `category` or " +
+							"`category_slug` with an attribute or in the body front matter.",
+						Optional: true,
+					},
+				},
+				Optional: true,
+			},
+			"expected_project_subdomain": schema.StringAttribute{
+				Description: "The subdomain of the ReadMe project the API token is expected to belong to. " +
+					"When set, the provider fails if the API token belongs to a different project.",
+				Optional: true,
+			},
+			"expected_project_name": schema.StringAttribute{
+				Description: "The name of the ReadMe project the API token is expected to belong to. " +
+					"When set, the provider fails if the API token belongs to a different project.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "The maximum number of concurrent requests to the ReadMe API. " +
+					"Defaults to no limit beyond Terraform's own parallelism. " +
+					"Regardless of this setting, changes to docs within the same category are made one at a time.",
+				Optional: true,
+			},
+			"retry": schema.SingleNestedAttribute{
+				Description: "Retry behavior for ReadMe API requests. Requests that are rate limited (HTTP 429) " +
+					"are retried for all request types. Server errors (HTTP 500, 502, 503, 504) and network errors " +
+					"are only retried for requests that are safe to repeat, so a create is never sent twice. " +
+					"The Retry-After response header is honored when present, up to `max_backoff`.",
+				MarkdownDescription: "Retry behavior for ReadMe API requests. Requests that are rate limited " +
+					"(HTTP 429) are retried for all request types. Server errors (HTTP 500, 502, 503, 504) and " +
+					"network errors are only retried for requests that are safe to repeat, so a create is never " +
+					"sent twice. The `Retry-After` response header is honored when present, up to `max_backoff`.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Description: "The maximum number of attempts for a request, including the first one. " +
+							"Set to 1 to disable retries. Defaults to 4.",
+						Optional: true,
+					},
+					"min_backoff": schema.Int64Attribute{
+						Description: "The minimum number of seconds to wait before retrying a request. Defaults to 1.",
+						Optional:    true,
+					},
+					"max_backoff": schema.Int64Attribute{
+						Description: "The maximum number of seconds to wait before retrying a request. " +
+							"This also caps the wait requested by the `Retry-After` response header. Defaults to 30.",
+						Optional: true,
+					},
 				},
 				Optional: true,
 			},
@@ -129,26 +237,32 @@ func (p *readmeProvider) Configure(
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
 	// Default values to environment variables, but override with Terraform configuration value if set.
-	apiToken := os.Getenv("README_API_TOKEN")
 	apiURL := os.Getenv("README_API_URL")
 
-	// Use the config value if it's set
-	if config.APIToken.ValueString() != "" {
-		apiToken = config.APIToken.ValueString()
-	}
-
 	if config.APIURL.ValueString() != "" {
 		apiURL = config.APIURL.ValueString()
 	}
 
-	// Ensure API token is set via the "api_token" attribute or README_API_TOKEN env var.
-	if apiToken == "" {
+	// Resolve the API token from the first source that is set.
+	var apiTokenCommand []string
+	resp.Diagnostics.Append(config.APITokenCommand.ElementsAs(ctx, &apiTokenCommand, false)...)
+
+	apiToken, apiTokenSource, diags := resolveAPIToken(ctx, apiTokenConfig{
+		Token:   config.APIToken.ValueString(),
+		File:    config.APITokenFile.ValueString(),
+		Command: apiTokenCommand,
+	})
+	resp.Diagnostics.Append(diags...)
+
+	// Ensure API token is set by one of the sources.
+	if apiToken == "" && !diags.HasError() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_token"),
 			"Missing ReadMe API Token.",
 			"The provider cannot create the Readme API client because there is a missing or empty value for the Readme API token. "+
-				"Set the token value in the configuration or use the README_API_TOKEN environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the token value in the configuration with the api_token, api_token_file, or api_token_command "+
+				"attributes, use the README_API_TOKEN environment variable, or log in with the rdme CLI. "+
+				"If any are already set, ensure the value is not empty.",
 		)
 	}
 
@@ -168,11 +282,33 @@ func (p *readmeProvider) Configure(
 		}
 	}
 
+	// Requests may be recorded to or replayed from a file for debugging, but not both.
+	recordPath := os.Getenv(envHTTPRecord)
+	replayPath := os.Getenv(envHTTPReplay)
+	if recordPath != "" && replayPath != "" {
+		resp.Diagnostics.AddError(
+			"Conflicting HTTP recording configuration.",
+			fmt.Sprintf("Only one of the %s and %s environment variables may be set.", envHTTPRecord, envHTTPReplay),
+		)
+	}
+
+	retry, diags := newRetryConfig(ctx, config.Retry)
+	resp.Diagnostics.Append(diags...)
+
+	if !config.MaxConcurrentRequests.IsNull() && config.MaxConcurrentRequests.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid maximum concurrent requests.",
+			"max_concurrent_requests must be at least 1. Remove the attribute to disable the limit.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	ctx = tflog.SetField(ctx, "api_token", apiToken)
+	ctx = tflog.SetField(ctx, "api_token_source", apiTokenSource)
 	ctx = tflog.SetField(ctx, "api_url", apiURL)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "api_token")
 
@@ -199,26 +335,68 @@ func (p *readmeProvider) Configure(
 		return
 	}
 
+	// Record or replay HTTP interactions for debugging when enabled by the environment.
+	switch {
+	case recordPath != "":
+		tflog.Warn(ctx, "Recording ReadMe API requests", map[string]any{"path": recordPath})
+		client.HTTPClient.Transport = newRecordTransport(recordPath, apiToken, client.HTTPClient.Transport)
+	case replayPath != "":
+		tflog.Warn(ctx, "Replaying ReadMe API requests", map[string]any{"path": replayPath})
+		replay, err := newReplayTransport(replayPath)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to replay ReadMe API requests.", err.Error())
+
+			return
+		}
+		client.HTTPClient.Transport = replay
+	}
+
+	// Limit the number of requests in flight. The limit applies to each attempt so that a request
+	// waiting to be retried doesn't hold a slot.
+	if !config.MaxConcurrentRequests.IsNull() {
+		client.HTTPClient.Transport = newLimitTransport(
+			int(config.MaxConcurrentRequests.ValueInt64()),
+			client.HTTPClient.Transport,
+		)
+	}
+
+	// Retry failed requests. Each attempt has its own timeout, so the client-wide timeout is
+	// replaced by the per-attempt timeout of the retry transport.
+	client.HTTPClient.Transport = newRetryTransport(ctx, retry, client.HTTPClient.Transport)
+	client.HTTPClient.Timeout = 0
+
+	// Ensure the API token belongs to the expected project before making any changes.
+	resp.Diagnostics.Append(p.verifyProject(ctx, client, apiURL, apiToken, apiTokenSource, projectGuard{
+		Subdomain: config.ExpectedProjectSubdomain.ValueString(),
+		Name:      config.ExpectedProjectName.ValueString(),
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Set the client in the provider data
-	attrs := config.Config.Attributes()
-	var destroyChildDocs types.Bool
-	if val, ok := attrs["destroy_child_docs"]; ok && val != nil {
-		destroyChildDocs = val.(types.Bool)
-	} else {
-		destroyChildDocs = types.BoolValue(false)
+	var features providerConfig
+	resp.Diagnostics.Append(config.Config.As(ctx, &features, basetypes.ObjectAsOptions{
+		UnhandledNullAsEmpty:    true,
+		UnhandledUnknownAsEmpty: true,
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	features := providerConfig{
-		DestroyChildDocs: destroyChildDocs,
+	if features.DestroyChildDocs.IsNull() {
+		features.DestroyChildDocs = types.BoolValue(false)
 	}
 
 	cfg := &providerData{
-		client: client,
-		config: features,
+		client:  client,
+		config:  features,
+		locks:   newCategoryLocks(),
+		lookups: newLookupCache(defaultLookupCacheTTL),
 	}
 
 	// Make the Readme client available during DataSource and Resource type Configure methods.
-	resp.DataSourceData = client
+	resp.DataSourceData = cfg
 	resp.ResourceData = cfg
 
 	tflog.Info(ctx, "Configured ReadMe client", map[string]any{"success": true})
@@ -238,6 +416,7 @@ func (p *readmeProvider) DataSources(_ context.Context) []func() datasource.Data
 		NewCustomPagesDataSource,
 		NewDocDataSource,
 		NewDocSearchDataSource,
+		NewDocsExportDataSource,
 		NewProjectDataSource,
 		NewVersionDataSource,
 		NewVersionsDataSource,
@@ -251,12 +430,46 @@ func (p *readmeProvider) Resources(_ context.Context) []func() resource.Resource
 		NewCategoryResource,
 		NewChangelogResource,
 		NewCustomPageResource,
+		NewDocCopyResource,
+		NewDocMultiResource,
+		NewDocOrderResource,
 		NewDocResource,
+		NewDocsDirectoryResource,
 		NewImageResource,
 		NewVersionResource,
 	}
 }
 
+// Functions defines the functions implemented in the provider.
+//
+// Functions don't make API requests so they can be used before the provider is configured.
+func (p *readmeProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewDocURLFunction,
+		NewParseFrontMatterFunction,
+		NewRenderFrontMatterFunction,
+		NewSlugifyFunction,
+		NewVersionCleanFunction,
+	}
+}
+
+// readOnly adds an error diagnostic and returns true if the provider is in read-only mode.
+//
+// Resources call this before making any changes so that nothing is modified in ReadMe.
+func (c providerConfig) readOnly(diags *diag.Diagnostics, action, resourceType string) bool {
+	if !c.ReadOnly.ValueBool() {
+		return false
+	}
+
+	diags.AddError(
+		"Provider is in read-only mode.",
+		fmt.Sprintf("Unable to %s %s because the provider's `config.read_only` attribute is set to true. "+
+			"Remove it or set it to false to allow changes.", action, resourceType),
+	)
+
+	return true
+}
+
 // boolPoint returns a pointer to a boolean.
 func boolPoint(input bool) *bool {
 	return &input
@@ -267,6 +480,25 @@ func intPoint(input int) *int {
 	return &input
 }
 
+// knownBoolPoint returns a pointer to a bool attribute value, or nil if the value is null or unknown.
+func knownBoolPoint(value types.Bool) *bool {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	return boolPoint(value.ValueBool())
+}
+
+// knownStringPoint returns a pointer to a string attribute value, or nil if the value is null or
+// unknown.
+func knownStringPoint(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	return value.ValueStringPointer()
+}
+
 // apiRequestOptions returns options for making the API request with a version if a version is set.
 // Otherwise, it returns an empty `readme.RequestOptions` struct.
 func apiRequestOptions(version basetypes.StringValue) readme.RequestOptions {
@@ -305,20 +537,10 @@ func versionClean(ctx context.Context, client *readme.Client, versionID string)
 // clientError is a helper function for formatting a Terraform diagnostics error response string
 // from the client library and API.
 //
-// It accepts the raw error and APIResponse struct. If the APIResponse includes an error message,
-// it will be appended to the error with a line break.
-// Functions that make an API request should use the returned string as the second argument to the
-// Terraform diagnostics AddError() function, which is used as the detailed message in a Terraform
-// error.
+// It accepts the raw error and APIResponse struct. If the APIResponse includes an error, the
+// ReadMe error message, suggestion, links, HTTP status, and request ID are included.
+// Functions that make an API request should prefer clientDiagnostic(), which also scopes known
+// errors to an attribute. This is used where the error must be returned as a string or error.
 func clientError(err error, apiResponse *readme.APIResponse) string {
-	diagErr := err.Error()
-
-	if apiResponse == nil || apiResponse.APIErrorResponse.Message == "" {
-		return diagErr
-	}
-
-	diagErr = fmt.Sprintf("API Error Message: %s\n", apiResponse.APIErrorResponse.Message)
-	diagErr += fmt.Sprintf("API Error Response: %+v", apiResponse.APIErrorResponse)
-
-	return diagErr
+	return clientErrorDetail(err, apiResponse)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/liveoaklabs/readme-api-go-client/readme"
+	"gopkg.in/h2non/gock.v1"
 )
 
 // NewTest sets up the provider for testing.
@@ -70,6 +71,9 @@ func TestProvider_MissingAPIToken(t *testing.T) {
 	prevValue := os.Getenv("README_API_TOKEN")
 	os.Setenv("README_API_TOKEN", "")
 
+	// Ensure an API key stored by the rdme CLI isn't found.
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
 	invalidProviderConfig := `
 	provider "readme" {
 		api_token = ""
@@ -118,3 +122,42 @@ func TestProvider_EmptyAPIURL(t *testing.T) {
 func escapeNewlines(s string) string {
 	return regexp.MustCompile(`\n`).ReplaceAllString(s, `\n`)
 }
+
+func TestProvider_ReadOnly(t *testing.T) {
+	defer gock.OffAll()
+
+	// Only the data source is mocked. The resource must fail before making a request.
+	gock.New(testURL).Get("/").Persist().Reply(200).JSON(map[string]string{"name": "Test Project"})
+
+	readOnlyProviderConfig := `
+	provider "readme" {
+		api_token = "` + testToken + `"
+		api_url   = "` + testURL + `"
+		config = {
+			read_only = true
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Data sources are unaffected.
+			{
+				Config: readOnlyProviderConfig + `data "readme_project" "test" {}`,
+				Check:  resource.TestCheckResourceAttr("data.readme_project.test", "name", "Test Project"),
+			},
+			// Creating a resource fails.
+			{
+				Config: readOnlyProviderConfig + `
+				resource "readme_category" "test" {
+					title = "Test"
+					type  = "guide"
+				}`,
+				ExpectError: regexp.MustCompile(`Unable to create category because the provider's\s+` +
+					"`config.read_only` attribute is set to true"),
+			},
+		},
+	})
+}
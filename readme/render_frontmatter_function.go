@@ -0,0 +1,202 @@
+package readme
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"gopkg.in/yaml.v2"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &renderFrontMatterFunction{}
+
+// renderFrontMatterFunction is the function implementation.
+type renderFrontMatterFunction struct{}
+
+// NewRenderFrontMatterFunction is a helper function to simplify the provider implementation.
+func NewRenderFrontMatterFunction() function.Function {
+	return &renderFrontMatterFunction{}
+}
+
+// Metadata returns the function name.
+func (f *renderFrontMatterFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "render_frontmatter"
+}
+
+// Definition defines the parameters and return type for the function.
+func (f *renderFrontMatterFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Render a Markdown document with front matter.",
+		Description: "Renders an object as YAML front matter at the top of a Markdown document.\n\n" +
+			"If the document already has front matter, the object's attributes are merged into it. " +
+			"Attributes that are null are removed from the front matter.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:           "frontmatter",
+				Description:    "An object or map of the front matter keys.",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:        "body",
+				Description: "The Markdown document.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run renders the front matter.
+func (f *renderFrontMatterFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+	var body string
+	resp.Error = req.Arguments.Get(ctx, &value, &body)
+	if resp.Error != nil {
+		return
+	}
+
+	if value.IsUnknown() || value.IsUnderlyingValueUnknown() {
+		resp.Error = function.NewArgumentFuncError(0, "The front matter must be known.")
+
+		return
+	}
+
+	updates := map[string]any{}
+	if !value.IsNull() && !value.IsUnderlyingValueNull() {
+		decoded, err := valueToFrontMatter(value.UnderlyingValue())
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to convert front matter: %s", err))
+
+			return
+		}
+
+		var ok bool
+		if updates, ok = decoded.(map[string]any); !ok {
+			resp.Error = function.NewArgumentFuncError(0, "The front matter must be an object or map.")
+
+			return
+		}
+	}
+
+	rendered, err := renderFrontMatter(body, updates)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, rendered)
+}
+
+// renderFrontMatter merges keys into the front matter of a Markdown document and returns the
+// document with the merged front matter. Keys with a nil value are removed.
+func renderFrontMatter(body string, updates map[string]any) (string, error) {
+	matter, content, err := parseFrontMatter(body)
+	if err != nil {
+		return "", err
+	}
+
+	for key, value := range updates {
+		if value == nil {
+			delete(matter, key)
+
+			continue
+		}
+		matter[key] = value
+	}
+
+	if len(matter) == 0 {
+		return content, nil
+	}
+
+	out, err := yaml.Marshal(matter)
+	if err != nil {
+		return "", fmt.Errorf("unable to render front matter: %w", err)
+	}
+
+	return "---\n" + string(out) + "---\n" + strings.TrimPrefix(content, "\n"), nil
+}
+
+// valueToFrontMatter converts a Terraform value to a value that can be encoded as YAML.
+//
+// Null attributes and map elements are returned as nil.
+func valueToFrontMatter(value attr.Value) (any, error) {
+	if value.IsNull() {
+		return nil, nil //nolint:nilnil // A null value is valid front matter.
+	}
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value must be known")
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return valueToFrontMatter(v.UnderlyingValue())
+	case basetypes.StringValue:
+		return v.ValueString(), nil
+	case basetypes.BoolValue:
+		return v.ValueBool(), nil
+	case basetypes.NumberValue:
+		if i, accuracy := v.ValueBigFloat().Int64(); accuracy == 0 {
+			return i, nil
+		}
+		f, _ := v.ValueBigFloat().Float64()
+
+		return f, nil
+	case basetypes.Int64Value:
+		return v.ValueInt64(), nil
+	case basetypes.Float64Value:
+		return v.ValueFloat64(), nil
+	case basetypes.ObjectValue:
+		return attrsToFrontMatter(v.Attributes())
+	case basetypes.MapValue:
+		return attrsToFrontMatter(v.Elements())
+	case basetypes.TupleValue:
+		return elemsToFrontMatter(v.Elements())
+	case basetypes.ListValue:
+		return elemsToFrontMatter(v.Elements())
+	case basetypes.SetValue:
+		return elemsToFrontMatter(v.Elements())
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", value.Type(context.Background()))
+}
+
+// attrsToFrontMatter converts the attributes of an object or elements of a map to front matter.
+func attrsToFrontMatter(attrs map[string]attr.Value) (map[string]any, error) {
+	matter := make(map[string]any, len(attrs))
+	for key, value := range attrs {
+		item, err := valueToFrontMatter(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		matter[key] = item
+	}
+
+	return matter, nil
+}
+
+// elemsToFrontMatter converts the elements of a tuple, list, or set to front matter.
+func elemsToFrontMatter(elems []attr.Value) ([]any, error) {
+	items := make([]any, 0, len(elems))
+	for _, elem := range elems {
+		item, err := valueToFrontMatter(elem)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,80 @@
+package readme
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRenderFrontMatterFunction(t *testing.T) {
+	matter := types.ObjectValueMust(
+		map[string]attr.Type{
+			"title":    types.StringType,
+			"order":    types.NumberType,
+			"hidden":   types.BoolType,
+			"excerpt":  types.StringType,
+			"keywords": types.ListType{ElemType: types.StringType},
+		},
+		map[string]attr.Value{
+			"title":    types.StringValue("Getting Started"),
+			"order":    types.NumberValue(big.NewFloat(2)),
+			"hidden":   types.BoolValue(true),
+			"excerpt":  types.StringNull(),
+			"keywords": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("readme")}),
+		},
+	)
+
+	tests := map[string]struct {
+		matter types.Dynamic
+		body   string
+		expect string
+	}{
+		"new front matter": {
+			matter: types.DynamicValue(matter),
+			body:   "# Welcome\n",
+			expect: "---\nhidden: true\nkeywords:\n- readme\norder: 2\ntitle: Getting Started\n---\n# Welcome\n",
+		},
+		"merged front matter": {
+			matter: types.DynamicValue(matter),
+			body:   "---\ntitle: Old\nexcerpt: Removed\ncategory: abc\n---\n\n# Welcome\n",
+			expect: "---\ncategory: abc\nhidden: true\nkeywords:\n- readme\norder: 2\ntitle: Getting Started\n---\n# Welcome\n",
+		},
+		"map": {
+			matter: types.DynamicValue(types.MapValueMust(types.StringType, map[string]attr.Value{
+				"slug": types.StringValue("welcome"),
+			})),
+			body:   "# Welcome\n",
+			expect: "---\nslug: welcome\n---\n# Welcome\n",
+		},
+		"null front matter": {
+			matter: types.DynamicNull(),
+			body:   "# Welcome\n",
+			expect: "# Welcome\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := runFunction(NewRenderFrontMatterFunction(), types.StringUnknown(), tc.matter, types.StringValue(tc.body))
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tc.expect)) {
+				t.Errorf("expected:\n%s\ngot:\n%s", tc.expect, got)
+			}
+		})
+	}
+
+	resp := runFunction(
+		NewRenderFrontMatterFunction(),
+		types.StringUnknown(),
+		types.DynamicValue(types.StringValue("title")),
+		types.StringValue("# Welcome\n"),
+	)
+	if resp.Error == nil {
+		t.Error("expected an error for front matter that isn't an object")
+	}
+}
@@ -0,0 +1,324 @@
+package readme
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultRetryMaxAttempts is the default number of attempts made for a request, including the
+	// first one.
+	defaultRetryMaxAttempts = 4
+
+	// defaultRetryMinBackoff is the default minimum time to wait before retrying a request.
+	defaultRetryMinBackoff = 1 * time.Second
+
+	// defaultRetryMaxBackoff is the default maximum time to wait before retrying a request.
+	defaultRetryMaxBackoff = 30 * time.Second
+
+	// defaultAttemptTimeout is the timeout for a single attempt of a request. This matches the
+	// timeout the ReadMe API client uses for its HTTP client.
+	defaultAttemptTimeout = 10 * time.Second
+)
+
+// retryModel maps the provider's `retry` configuration attribute.
+type retryModel struct {
+	MaxAttempts types.Int64 `tfsdk:"max_attempts"`
+	MinBackoff  types.Int64 `tfsdk:"min_backoff"`
+	MaxBackoff  types.Int64 `tfsdk:"max_backoff"`
+}
+
+// retryConfig is the resolved retry behavior for ReadMe API requests.
+type retryConfig struct {
+	MaxAttempts    int
+	MinBackoff     time.Duration
+	MaxBackoff     time.Duration
+	AttemptTimeout time.Duration
+}
+
+// newRetryConfig returns the retry configuration from the provider's `retry` attribute, using
+// defaults for any values that aren't set.
+func newRetryConfig(ctx context.Context, value types.Object) (retryConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	cfg := retryConfig{
+		MaxAttempts:    defaultRetryMaxAttempts,
+		MinBackoff:     defaultRetryMinBackoff,
+		MaxBackoff:     defaultRetryMaxBackoff,
+		AttemptTimeout: defaultAttemptTimeout,
+	}
+
+	if value.IsNull() || value.IsUnknown() {
+		return cfg, diags
+	}
+
+	var model retryModel
+	diags.Append(value.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return cfg, diags
+	}
+
+	if !model.MaxAttempts.IsNull() {
+		if model.MaxAttempts.ValueInt64() < 1 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("max_attempts"),
+				"Invalid retry configuration.",
+				"max_attempts must be at least 1. Set it to 1 to disable retries.",
+			)
+		}
+		cfg.MaxAttempts = int(model.MaxAttempts.ValueInt64())
+	}
+
+	if !model.MinBackoff.IsNull() {
+		if model.MinBackoff.ValueInt64() < 0 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("min_backoff"),
+				"Invalid retry configuration.",
+				"min_backoff must not be negative.",
+			)
+		}
+		cfg.MinBackoff = time.Duration(model.MinBackoff.ValueInt64()) * time.Second
+	}
+
+	if !model.MaxBackoff.IsNull() {
+		cfg.MaxBackoff = time.Duration(model.MaxBackoff.ValueInt64()) * time.Second
+	}
+
+	if cfg.MaxBackoff < cfg.MinBackoff {
+		diags.AddAttributeError(
+			path.Root("retry").AtName("max_backoff"),
+			"Invalid retry configuration.",
+			fmt.Sprintf("max_backoff (%s) must not be less than min_backoff (%s).", cfg.MaxBackoff, cfg.MinBackoff),
+		)
+	}
+
+	return cfg, diags
+}
+
+// retryTransport is an http.RoundTripper that retries ReadMe API requests that fail with a
+// transient error.
+//
+// Requests that are rate limited (HTTP 429) are always retried, since ReadMe rejects them before
+// they're processed. Server errors and network errors are only retried for idempotent requests so
+// that a create is never sent twice after ReadMe may have already processed it.
+type retryTransport struct {
+	// ctx is used for logging retried requests.
+	ctx    context.Context
+	config retryConfig
+	// next is the transport used to perform each attempt. http.DefaultTransport is used when nil.
+	next http.RoundTripper
+	// sleep waits for the specified duration before the next attempt.
+	sleep func(ctx context.Context, wait time.Duration) error
+}
+
+// newRetryTransport returns a retryTransport that wraps the `next` transport.
+func newRetryTransport(ctx context.Context, config retryConfig, next http.RoundTripper) *retryTransport {
+	return &retryTransport{
+		ctx:    ctx,
+		config: config,
+		next:   next,
+		sleep:  sleepContext,
+	}
+}
+
+// RoundTrip performs the request, retrying it as configured.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := t.attempt(req, attempt)
+
+		if attempt >= t.config.MaxAttempts || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		fields := map[string]any{
+			"method":       req.Method,
+			"url":          req.URL.String(),
+			"attempt":      attempt,
+			"max_attempts": t.config.MaxAttempts,
+			"wait":         wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		if resp != nil {
+			fields["status"] = resp.StatusCode
+
+			// Discard the failed response so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		tflog.Warn(t.ctx, "ReadMe API request failed, retrying", fields)
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, fmt.Errorf("request canceled while waiting to retry: %w", err)
+		}
+	}
+}
+
+// attempt performs a single attempt of the request with a fresh copy of the body and its own
+// timeout.
+func (t *retryTransport) attempt(req *http.Request, attempt int) (*http.Response, error) {
+	ctx := req.Context()
+	cancel := context.CancelFunc(func() {})
+	if t.config.AttemptTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.config.AttemptTimeout)
+	}
+
+	attemptReq := req.Clone(ctx)
+	if attempt > 1 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+
+			return nil, fmt.Errorf("unable to reset request body for retry: %w", err)
+		}
+		attemptReq.Body = body
+	}
+
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(attemptReq)
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	// The timeout must remain in effect until the caller is done reading the body.
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// backoff returns how long to wait before the next attempt.
+//
+// The Retry-After response header is honored when present, capped at the configured maximum so a
+// server can't block the apply for longer than the user allows. Otherwise, an exponential backoff
+// with jitter between the configured minimum and maximum is used.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return min(wait, t.config.MaxBackoff)
+		}
+	}
+
+	wait := t.config.MinBackoff << (attempt - 1)
+	if wait > t.config.MaxBackoff || wait < t.config.MinBackoff {
+		wait = t.config.MaxBackoff
+	}
+
+	// Randomize the second half of the wait so that concurrent requests don't retry in lockstep.
+	if half := wait / 2; half > 0 {
+		wait = half + rand.N(half+1)
+	}
+
+	return wait
+}
+
+// retryAfter parses a Retry-After header value, which may be a number of seconds or an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// retryable determines if a request should be retried based on its response or error.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	// Don't retry when the caller has given up.
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return idempotent(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return idempotent(req)
+	}
+
+	return false
+}
+
+// idempotent determines if a request can safely be sent more than once.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		// Searching docs is a POST request that doesn't modify anything.
+		return strings.HasSuffix(req.URL.Path, "/docs/search")
+	}
+
+	return false
+}
+
+// sleepContext waits for the specified duration or until the context is canceled.
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelOnClose is a response body that cancels the request's context when closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the response body and cancels the request's context.
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+
+	return err //nolint:wrapcheck // The error is passed through from the response body.
+}
@@ -0,0 +1,303 @@
+package readme
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// fakeTransport is an http.RoundTripper that returns the next response from a list of responses
+// and records the request bodies it receives.
+type fakeTransport struct {
+	responses []*http.Response
+	errs      []error
+	bodies    []string
+}
+
+func (f *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	i := len(f.bodies)
+
+	body := ""
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		body = string(b)
+	}
+	f.bodies = append(f.bodies, body)
+
+	if i < len(f.errs) && f.errs[i] != nil {
+		return nil, f.errs[i]
+	}
+
+	return f.responses[i], nil
+}
+
+// fakeResponse returns an HTTP response with the specified status code.
+func fakeResponse(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+	}
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+
+	return resp
+}
+
+// testRetryTransport returns a retryTransport that doesn't wait between attempts and records the
+// wait times it would have used.
+func testRetryTransport(next http.RoundTripper, waits *[]time.Duration) *retryTransport {
+	transport := newRetryTransport(context.Background(), retryConfig{
+		MaxAttempts:    3,
+		MinBackoff:     time.Second,
+		MaxBackoff:     4 * time.Second,
+		AttemptTimeout: time.Second,
+	}, next)
+	transport.sleep = func(_ context.Context, wait time.Duration) error {
+		*waits = append(*waits, wait)
+
+		return nil
+	}
+
+	return transport
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := map[string]struct {
+		method         string
+		path           string
+		responses      []*http.Response
+		errs           []error
+		expectStatus   int
+		expectError    bool
+		expectAttempts int
+	}{
+		"GET is retried on server errors": {
+			method: http.MethodGet,
+			path:   "/docs/test",
+			responses: []*http.Response{
+				fakeResponse(503, nil),
+				fakeResponse(502, nil),
+				fakeResponse(200, nil),
+			},
+			expectStatus:   200,
+			expectAttempts: 3,
+		},
+		"GET stops retrying after max attempts": {
+			method: http.MethodGet,
+			path:   "/docs/test",
+			responses: []*http.Response{
+				fakeResponse(500, nil),
+				fakeResponse(500, nil),
+				fakeResponse(500, nil),
+			},
+			expectStatus:   500,
+			expectAttempts: 3,
+		},
+		"GET is retried on transport errors": {
+			method:         http.MethodGet,
+			path:           "/docs/test",
+			responses:      []*http.Response{nil, fakeResponse(200, nil)},
+			errs:           []error{errors.New("connection reset")},
+			expectStatus:   200,
+			expectAttempts: 2,
+		},
+		"POST is retried when rate limited": {
+			method:         http.MethodPost,
+			path:           "/docs",
+			responses:      []*http.Response{fakeResponse(429, nil), fakeResponse(201, nil)},
+			expectStatus:   201,
+			expectAttempts: 2,
+		},
+		"POST is not retried on server errors": {
+			method:         http.MethodPost,
+			path:           "/docs",
+			responses:      []*http.Response{fakeResponse(500, nil)},
+			expectStatus:   500,
+			expectAttempts: 1,
+		},
+		"POST is not retried on transport errors": {
+			method:         http.MethodPost,
+			path:           "/docs",
+			responses:      []*http.Response{nil},
+			errs:           []error{errors.New("connection reset")},
+			expectError:    true,
+			expectAttempts: 1,
+		},
+		"POST search is retried on server errors": {
+			method:         http.MethodPost,
+			path:           "/docs/search",
+			responses:      []*http.Response{fakeResponse(503, nil), fakeResponse(200, nil)},
+			expectStatus:   200,
+			expectAttempts: 2,
+		},
+		"client errors are not retried": {
+			method:         http.MethodGet,
+			path:           "/docs/test",
+			responses:      []*http.Response{fakeResponse(404, nil)},
+			expectStatus:   404,
+			expectAttempts: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var waits []time.Duration
+			next := &fakeTransport{responses: tc.responses, errs: tc.errs}
+			transport := testRetryTransport(next, &waits)
+
+			req, _ := http.NewRequest(tc.method, testURL+tc.path, strings.NewReader(`{"title":"test"}`))
+			resp, err := transport.RoundTrip(req)
+
+			if tc.expectError && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectError {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if resp.StatusCode != tc.expectStatus {
+					t.Errorf("expected status %d, got %d", tc.expectStatus, resp.StatusCode)
+				}
+			}
+
+			if len(next.bodies) != tc.expectAttempts {
+				t.Errorf("expected %d attempts, got %d", tc.expectAttempts, len(next.bodies))
+			}
+
+			// The full request body must be sent on every attempt.
+			for i, body := range next.bodies {
+				if tc.method == http.MethodPost && body != `{"title":"test"}` {
+					t.Errorf("attempt %d: unexpected request body %q", i+1, body)
+				}
+			}
+
+			if len(waits) != tc.expectAttempts-1 {
+				t.Errorf("expected %d waits, got %d", tc.expectAttempts-1, len(waits))
+			}
+		})
+	}
+}
+
+func TestRetryTransport_RetryAfter(t *testing.T) {
+	var waits []time.Duration
+	next := &fakeTransport{responses: []*http.Response{
+		fakeResponse(429, map[string]string{"Retry-After": "7"}),
+		fakeResponse(200, nil),
+	}}
+	transport := testRetryTransport(next, &waits)
+
+	req, _ := http.NewRequest(http.MethodGet, testURL+"/docs/test", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The Retry-After wait is capped at the maximum backoff.
+	if len(waits) != 1 || waits[0] != 4*time.Second {
+		t.Errorf("expected a single wait of 4s, got %v", waits)
+	}
+}
+
+func TestRetryTransport_Backoff(t *testing.T) {
+	transport := testRetryTransport(nil, &[]time.Duration{})
+
+	for attempt, max := range map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 4 * time.Second,
+		// The backoff is capped at the maximum even when shifting overflows.
+		80: 4 * time.Second,
+	} {
+		wait := transport.backoff(attempt, nil)
+		if wait < max/2 || wait > max {
+			t.Errorf("attempt %d: expected wait between %s and %s, got %s", attempt, max/2, max, wait)
+		}
+	}
+}
+
+func TestRetryTransport_BackoffRetryAfter(t *testing.T) {
+	transport := testRetryTransport(nil, &[]time.Duration{})
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "2")
+	if wait := transport.backoff(1, resp); wait != 2*time.Second {
+		t.Errorf("expected the Retry-After wait of 2s, got %s", wait)
+	}
+
+	// A longer wait than the maximum backoff is capped.
+	resp.Header.Set("Retry-After", "3600")
+	if wait := transport.backoff(1, resp); wait != transport.config.MaxBackoff {
+		t.Errorf("expected the Retry-After wait to be capped at %s, got %s", transport.config.MaxBackoff, wait)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		"empty":        {value: "", ok: false},
+		"seconds":      {value: "12", expected: 12 * time.Second, ok: true},
+		"negative":     {value: "-1", ok: false},
+		"http date":    {value: "Sun, 01 Jan 2023 00:00:30 GMT", expected: 30 * time.Second, ok: true},
+		"date in past": {value: "Sat, 31 Dec 2022 23:59:00 GMT", expected: 0, ok: true},
+		"invalid":      {value: "soon", ok: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wait, ok := retryAfter(tc.value, now)
+			if ok != tc.ok || wait != tc.expected {
+				t.Errorf("expected (%s, %t), got (%s, %t)", tc.expected, tc.ok, wait, ok)
+			}
+		})
+	}
+}
+
+func TestProvider_InvalidRetry(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "readme" {
+					api_token = "` + testToken + `"
+					api_url   = "` + testURL + `"
+					retry = {
+						max_attempts = 0
+					}
+				}
+				data "readme_project" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`max_attempts must be at least 1`),
+			},
+			{
+				Config: `
+				provider "readme" {
+					api_token = "` + testToken + `"
+					api_url   = "` + testURL + `"
+					retry = {
+						min_backoff = 10
+						max_backoff = 5
+					}
+				}
+				data "readme_project" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`must not be less than min_backoff`),
+			},
+		},
+	})
+}
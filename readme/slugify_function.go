@@ -0,0 +1,98 @@
+package readme
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &slugifyFunction{}
+
+// slugifyFunction is the function implementation.
+type slugifyFunction struct{}
+
+// NewSlugifyFunction is a helper function to simplify the provider implementation.
+func NewSlugifyFunction() function.Function {
+	return &slugifyFunction{}
+}
+
+// Metadata returns the function name.
+func (f *slugifyFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "slugify"
+}
+
+// Definition defines the parameters and return type for the function.
+func (f *slugifyFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Convert a title to a ReadMe slug.",
+		Description: "Converts a title to the slug ReadMe generates for it. The title is lowercased, " +
+			"accents are removed, characters other than letters and numbers are replaced with a hyphen, " +
+			"and repeated, leading, and trailing hyphens are removed.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "title",
+				Description: "The title to convert.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run converts the title to a slug.
+func (f *slugifyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var title string
+	resp.Error = req.Arguments.Get(ctx, &title)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, slugify(title))
+}
+
+// slugify converts a title to a slug the way ReadMe normalizes titles and slugs.
+func slugify(title string) string {
+	// Remove accents by decomposing characters and dropping the combining marks.
+	unaccented, _, err := transform.String(
+		transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC),
+		title,
+	)
+	if err != nil {
+		unaccented = title
+	}
+
+	var slug strings.Builder
+	hyphen := false
+
+	for _, char := range strings.ToLower(unaccented) {
+		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') {
+			if hyphen && slug.Len() > 0 {
+				slug.WriteRune('-')
+			}
+			slug.WriteRune(char)
+			hyphen = false
+
+			continue
+		}
+
+		// Apostrophes are removed rather than separating words.
+		if char != '\'' && char != '’' {
+			hyphen = true
+		}
+	}
+
+	return slug.String()
+}
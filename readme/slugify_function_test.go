@@ -0,0 +1,44 @@
+package readme
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction runs a provider function with the arguments and returns the response. The result is
+// initialized with an unknown value of the result type.
+func runFunction(f function.Function, result attr.Value, args ...attr.Value) function.RunResponse {
+	resp := function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+
+	return resp
+}
+
+func TestSlugifyFunction(t *testing.T) {
+	tests := map[string]string{
+		"Getting Started":        "getting-started",
+		"  Hello,   World!  ":    "hello-world",
+		"What's New in v2.0?":    "whats-new-in-v2-0",
+		"API_Reference & Guides": "api-reference-guides",
+		"already-a-slug":         "already-a-slug",
+		"---":                    "",
+		"Crème Brûlée, 123":      "creme-brulee-123",
+	}
+
+	for title, expected := range tests {
+		t.Run(title, func(t *testing.T) {
+			resp := runFunction(NewSlugifyFunction(), types.StringUnknown(), types.StringValue(title))
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(expected)) {
+				t.Errorf("expected '%s', got %s", expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package readme
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &versionCleanFunction{}
+
+// versionCleanFunction is the function implementation.
+type versionCleanFunction struct{}
+
+// NewVersionCleanFunction is a helper function to simplify the provider implementation.
+func NewVersionCleanFunction() function.Function {
+	return &versionCleanFunction{}
+}
+
+// Metadata returns the function name.
+func (f *versionCleanFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "version_clean"
+}
+
+// Definition defines the parameters and return type for the function.
+func (f *versionCleanFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a ReadMe version.",
+		Description: "Returns the clean form of a version that ReadMe uses in the `version_clean` " +
+			"attribute and in URLs. Surrounding whitespace and a leading `=` or `v` are removed, " +
+			"such as `v1.2.0` becoming `1.2.0`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "version",
+				Description: "The version to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the version.
+func (f *versionCleanFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var version string
+	resp.Error = req.Arguments.Get(ctx, &version)
+	if resp.Error != nil {
+		return
+	}
+
+	clean := cleanVersion(version)
+	if clean == "" {
+		resp.Error = function.NewArgumentFuncError(0, "The version must not be empty.")
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, clean)
+}
+
+// cleanVersion returns a version without surrounding whitespace or a leading `=` or `v`.
+func cleanVersion(version string) string {
+	version = strings.TrimSpace(version)
+	version = strings.TrimPrefix(version, "=")
+	version = strings.TrimPrefix(version, "v")
+	version = strings.TrimPrefix(version, "V")
+
+	return strings.TrimSpace(version)
+}
@@ -0,0 +1,35 @@
+package readme
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestVersionCleanFunction(t *testing.T) {
+	tests := map[string]string{
+		"1.2.0":     "1.2.0",
+		"v1.2.0":    "1.2.0",
+		" =v1.1 ":   "1.1",
+		"V2":        "2",
+		"2024-beta": "2024-beta",
+	}
+
+	for version, expected := range tests {
+		t.Run(version, func(t *testing.T) {
+			resp := runFunction(NewVersionCleanFunction(), types.StringUnknown(), types.StringValue(version))
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(expected)) {
+				t.Errorf("expected '%s', got %s", expected, got)
+			}
+		})
+	}
+
+	resp := runFunction(NewVersionCleanFunction(), types.StringUnknown(), types.StringValue(" v "))
+	if resp.Error == nil {
+		t.Error("expected an error for an empty version")
+	}
+}
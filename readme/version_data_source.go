@@ -177,7 +177,7 @@ func (d *versionDataSource) Read(
 	// Get the version.
 	version, apiResponse, err := d.client.Version.Get(reqVersion)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to read version.", clientError(err, apiResponse))
+		resp.Diagnostics.Append(clientDiagnostic("Unable to read version.", err, apiResponse, nil))
 
 		return
 	}
@@ -224,5 +224,5 @@ func (d *versionDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }
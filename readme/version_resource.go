@@ -27,8 +27,9 @@ var (
 
 // versionResource is the data source implementation.
 type versionResource struct {
-	client *readme.Client
-	config providerConfig
+	client  *readme.Client
+	config  providerConfig
+	lookups *lookupCache
 }
 
 // versionResourceModel maps the struct from the ReadMe client library to Terraform resource attributes.
@@ -76,6 +77,7 @@ func (r *versionResource) Configure(
 	cfg := req.ProviderData.(*providerData)
 	r.client = cfg.client
 	r.config = cfg.config
+	r.lookups = cfg.lookups
 }
 
 // ValidateConfig is used for validating attribute values.
@@ -265,6 +267,10 @@ func (r *versionResource) Create(
 	req resource.CreateRequest,
 	resp *resource.CreateResponse,
 ) {
+	if r.config.readOnly(&resp.Diagnostics, "create", "version") {
+		return
+	}
+
 	// Retrieve values from plan.
 	var plan versionResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -312,7 +318,7 @@ func (r *versionResource) Read(
 			return
 		}
 
-		resp.Diagnostics.AddError("Unable to read version metadata.", err.Error())
+		resp.Diagnostics.Append(clientDiagnostic("Unable to read version metadata.", err, apiResponse, nil))
 
 		return
 	}
@@ -330,6 +336,10 @@ func (r *versionResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
+	if r.config.readOnly(&resp.Diagnostics, "update", "version") {
+		return
+	}
+
 	// Retrieve values from plan and current state.
 	var plan, state versionResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -359,6 +369,10 @@ func (r *versionResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
+	if r.config.readOnly(&resp.Diagnostics, "delete", "version") {
+		return
+	}
+
 	// Retrieve values from state.
 	var state versionResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -368,11 +382,14 @@ func (r *versionResource) Delete(
 
 	// Delete the version.
 	_, apiResponse, err := r.client.Version.Delete(state.VersionClean.ValueString())
+	r.lookups.invalidate(lookupVersion)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(clientDiagnostic(
 			fmt.Sprintf("Unable to delete version %s.", state.VersionClean),
-			clientError(err, apiResponse),
-		)
+			err,
+			apiResponse,
+			nil,
+		))
 
 		return
 	}
@@ -471,6 +488,9 @@ func (r *versionResource) save(
 		return versionResourceModel{}, errors.New(clientError(err, apiResponse))
 	}
 
+	// The version may have been renamed.
+	r.lookups.invalidate(lookupVersion)
+
 	plan, _, err = r.get(createdVersion.VersionClean, plan)
 	if err != nil {
 		return plan, err
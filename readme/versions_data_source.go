@@ -117,7 +117,7 @@ func (d *versionsDataSource) Read(
 	// Get Versions list.
 	versions, apiResponse, err := d.client.Version.GetAll()
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to read versions.", clientError(err, apiResponse))
+		resp.Diagnostics.Append(clientDiagnostic("Unable to read versions.", err, apiResponse, nil))
 
 		return
 	}
@@ -163,5 +163,5 @@ func (d *versionsDataSource) Configure(
 		return
 	}
 
-	d.client = req.ProviderData.(*readme.Client)
+	d.client = req.ProviderData.(*providerData).client
 }